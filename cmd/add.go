@@ -1,15 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"javaman/internal/config"
 	"javaman/internal/detect"
-	"javaman/internal/env"
 
 	"github.com/spf13/cobra"
 )
@@ -25,7 +23,9 @@ Examples:
   Linux:   javaman add /usr/lib/jvm/java-17-openjdk-amd64
   macOS:   javaman add /Library/Java/JavaVirtualMachines/jdk-17.jdk/Contents/Home
 
-The version will be detected automatically from the JDK installation.`,
+The identifier is derived from the JDK's release file as <vendor>-<version>,
+for example temurin-17.0.9 or zulu-17.0.8, so several JDKs with the same
+major version can be managed side by side. Use --id to choose your own.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
@@ -36,19 +36,38 @@ The version will be detected automatically from the JDK installation.`,
 			return fmt.Errorf("failed to get absolute path: %w", err)
 		}
 
-		// 验证JDK路径
-		if !env.IsValidJDKPath(absPath) {
-			return fmt.Errorf("invalid JDK path: %s", absPath)
+		// 验证JDK路径，同时获取版本号
+		probe := detect.ProbeJDK(context.Background(), absPath)
+		if !probe.Valid {
+			return fmt.Errorf("invalid JDK path: %s: %v", absPath, probe.Err)
 		}
 
-		// 从java命令获取版本信息
-		version, err := getJavaVersion(absPath)
-		if err != nil {
-			// 如果无法从命令获取版本，尝试从路径名获取
-			version = detect.ExtractVersionFromDirName(filepath.Base(absPath))
-			if version == "" {
-				return fmt.Errorf("could not determine JDK version")
+		// 检查路径是否已被管理
+		for id, path := range config.GetVersions() {
+			if path == absPath {
+				return fmt.Errorf("JDK at %s is already managed as %s", absPath, id)
+			}
+		}
+
+		// 配置中的键不区分大小写，统一使用小写
+		version := strings.ToLower(addID)
+		if version == "" {
+			version, err = identifyJDK(absPath, probe.Version)
+			if err != nil {
+				return err
 			}
+		} else if !detect.ValidID(version) {
+			// 标识会用作配置中的键以及shim和toolchain中的名称，与自动生成的标识使用相同的字符
+			return fmt.Errorf("invalid ID %q: only letters, digits, '.', '_', '+' and '-' are allowed", addID)
+		}
+
+		// 不覆盖已存在的版本
+		if existing, ok := config.GetVersions()[version]; ok {
+			return fmt.Errorf("version %s already exists at %s, use --id to choose another identifier", version, existing)
+		}
+		// 版本标识优先于同名别名，添加后该别名将不再生效
+		if target, ok := config.GetConfig().Aliases[version]; ok {
+			return fmt.Errorf("%s is already an alias for %s, remove it with 'javaman alias rm %s' or use --id to choose another identifier", version, target, version)
 		}
 
 		// 添加到配置
@@ -56,40 +75,39 @@ The version will be detected automatically from the JDK installation.`,
 			return fmt.Errorf("failed to add version: %w", err)
 		}
 
+		// 同步shim和toolchain配置
+		if err := syncInstallations(); err != nil {
+			return err
+		}
+
 		fmt.Printf("Added JDK version %s\n", version)
 		fmt.Printf("Path: %s\n", absPath)
 		return nil
 	},
 }
 
+var addID string
+
 func init() {
+	addCmd.Flags().StringVar(&addID, "id", "", "identifier for the JDK (default <vendor>-<version>)")
 	rootCmd.AddCommand(addCmd)
 }
 
-// getJavaVersion 通过运行java -version命令获取版本信息
-func getJavaVersion(jdkPath string) (string, error) {
-	javaExe := "java"
-	if runtime.GOOS == "windows" {
-		javaExe = "java.exe"
+// identifyJDK 为JDK生成标识，优先使用release文件，其次使用java -version和目录名中的主版本号
+func identifyJDK(jdkPath, javaVersion string) (string, error) {
+	info, _ := detect.ParseRelease(jdkPath)
+	if id := detect.JDKID(info, ""); id != "" {
+		return id, nil
 	}
 
-	cmd := exec.Command(filepath.Join(jdkPath, "bin", javaExe), "-version")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", err
+	// 使用java -version输出的版本号
+	if javaVersion != "" {
+		return detect.NormalizeVersion(javaVersion), nil
 	}
 
-	versionStr := string(output)
-	// 查找版本信息
-	lines := strings.Split(versionStr, "\n")
-	if len(lines) > 0 {
-		firstLine := strings.ToLower(lines[0])
-		if strings.Contains(firstLine, "version") {
-			parts := strings.Split(firstLine, `"`)
-			if len(parts) > 1 {
-				return detect.NormalizeVersion(parts[1]), nil
-			}
-		}
+	// 如果无法从命令获取版本，尝试从路径名获取
+	if version := detect.ExtractVersionFromDirName(filepath.Base(jdkPath)); version != "" {
+		return version, nil
 	}
-	return "", fmt.Errorf("could not parse version information")
+	return "", fmt.Errorf("could not determine JDK version")
 }
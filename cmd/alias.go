@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"javaman/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage version aliases",
+	Long: `Manage names that can be used instead of a version in 'use', 'env',
+'exec', 'local' and the default setting.
+
+An alias can point to a version ID (temurin-21.0.2), a version range
+(21, 21+, temurin-21) or another alias. Ranges are resolved each time the
+alias is used, so 'lts -> 21' follows new 21.x installations.`,
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set [name] [target]",
+	Short: "Create or change an alias",
+	Long: `Create an alias, or change the target of an existing one.
+The target must match at least one installed JDK.
+
+Examples:
+  javaman alias set lts 21             # Highest installed 21.x
+  javaman alias set latest 21+         # Highest installed 21 or later
+  javaman alias set work temurin-17    # Highest installed Temurin 17
+  javaman alias set stable lts         # Alias of an alias`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// 配置中的键不区分大小写，统一使用小写；目标可能是其他别名，同样使用小写
+		name, target := strings.ToLower(args[0]), strings.ToLower(args[1])
+		if err := config.SetAlias(name, target); err != nil {
+			return err
+		}
+
+		version, _, err := config.Lookup(name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Alias %s -> %s (currently %s)\n", name, target, version)
+		return nil
+	},
+}
+
+var aliasRmCmd = &cobra.Command{
+	Use:          "rm [name]",
+	Aliases:      []string{"remove"},
+	Short:        "Remove an alias",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if err := config.RemoveAlias(name); err != nil {
+			return err
+		}
+		fmt.Printf("Removed alias %s\n", name)
+		return nil
+	},
+}
+
+var aliasLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List aliases and the versions they resolve to",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		aliases := config.GetConfig().Aliases
+		if len(aliases) == 0 {
+			fmt.Println("No aliases defined. Create one with 'javaman alias set <name> <version>'")
+			return nil
+		}
+
+		names := make([]string, 0, len(aliases))
+		for name := range aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			version, _, err := config.Lookup(name)
+			if err != nil {
+				fmt.Printf("  %-10s -> %-16s (%v)\n", name, aliases[name], err)
+				continue
+			}
+			fmt.Printf("  %-10s -> %-16s %s\n", name, aliases[name], version)
+		}
+		return nil
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasRmCmd)
+	aliasCmd.AddCommand(aliasLsCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"javaman/internal/config"
+	"javaman/internal/detect"
 	"javaman/internal/env"
+	"javaman/internal/resolve"
 
 	"github.com/spf13/cobra"
 )
@@ -15,7 +17,9 @@ var currentCmd = &cobra.Command{
 	Long: `Display the currently active JDK version and its installation path.
 This command shows:
 - Current JAVA_HOME path
-- Current JDK version
+- Current JDK version with vendor, full version, architecture and build date
+- Version selected for the current directory and the rule that selected it
+  (project file, env override or default)
 - Last explicitly selected version`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		currentJavaHome, err := env.GetJavaHome()
@@ -23,34 +27,43 @@ This command shows:
 			return fmt.Errorf("failed to get current JAVA_HOME: %w", err)
 		}
 
-		if currentJavaHome == "" {
-			fmt.Println("No JDK currently active (JAVA_HOME not set)")
-			return nil
-		}
-
 		// 获取配置信息
 		cfg := config.GetConfig()
 
-		// 查找当前JAVA_HOME对应的版本
-		var currentVersion string
-		for version, path := range cfg.Versions {
-			if path == currentJavaHome {
-				currentVersion = version
-				break
+		fmt.Println("Current Java Environment:")
+		fmt.Println("------------------------")
+		if currentJavaHome == "" {
+			fmt.Println("No JDK currently active (JAVA_HOME not set)")
+		} else {
+			// 查找当前JAVA_HOME对应的版本
+			var currentVersion string
+			for version, path := range cfg.Versions {
+				if path == currentJavaHome {
+					currentVersion = version
+					break
+				}
+			}
+
+			fmt.Printf("JAVA_HOME: %s\n", currentJavaHome)
+			if currentVersion != "" {
+				fmt.Printf("Version:    %s\n", currentVersion)
+				if info, ok := config.GetInfo(currentVersion); ok {
+					printJDKInfo(info)
+				}
+			} else {
+				fmt.Printf("Version:    Unknown (path not managed by javaman)\n")
 			}
 		}
 
-		fmt.Println("Current Java Environment:")
-		fmt.Println("------------------------")
-		fmt.Printf("JAVA_HOME: %s\n", currentJavaHome)
-		if currentVersion != "" {
-			fmt.Printf("Version:    %s\n", currentVersion)
+		// 显示当前目录解析出的版本
+		if sel, err := resolve.Resolve(); err == nil {
+			fmt.Printf("\nSelected version:      %s (%s: %s)\n", sel.Version, sel.Source, sel.Origin)
 		} else {
-			fmt.Printf("Version:    Unknown (path not managed by javaman)\n")
+			fmt.Printf("\nSelected version:      none (%s)\n", err)
 		}
 
 		if cfg.Settings.LastUsed != "" {
-			fmt.Printf("\nLast selected version: %s\n", cfg.Settings.LastUsed)
+			fmt.Printf("Last selected version: %s\n", cfg.Settings.LastUsed)
 		}
 
 		if cfg.Settings.Default != "" {
@@ -64,3 +77,25 @@ This command shows:
 func init() {
 	rootCmd.AddCommand(currentCmd)
 }
+
+// printJDKInfo 输出JDK的详细元数据
+func printJDKInfo(info *detect.JDKInfo) {
+	if info.Vendor != "" {
+		fmt.Printf("Vendor:     %s\n", info.Vendor)
+	}
+	if info.FullVersion() != "" {
+		fmt.Printf("Full:       %s\n", info.FullVersion())
+	}
+	if info.ImplementorVersion != "" {
+		fmt.Printf("Build:      %s\n", info.ImplementorVersion)
+	}
+	if info.BuildDate != "" {
+		fmt.Printf("Date:       %s\n", info.BuildDate)
+	}
+	if info.Arch != "" {
+		fmt.Printf("Arch:       %s\n", info.Arch)
+	}
+	if len(info.Modules) > 0 {
+		fmt.Printf("Modules:    %d\n", len(info.Modules))
+	}
+}
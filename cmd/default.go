@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+
+	"javaman/internal/config"
+	"javaman/internal/env"
+	"javaman/internal/resolve"
+
+	"github.com/spf13/cobra"
+)
+
+var defaultUnset bool
+
+var defaultCmd = &cobra.Command{
+	Use:   "default [version]",
+	Short: "Set the default JDK version",
+	Long: `Set the JDK version used when neither the current shell ('javaman use')
+nor a project file (see 'javaman local') selects one.
+
+The default is applied by shims, and by the shell integration (see
+'javaman init') when a new shell starts. The version can be an ID, an
+alias or a version range; ranges and aliases are resolved each time the
+default is used.
+
+Note that 'javaman use --global' writes a fixed JAVA_HOME into the shell
+startup files. It overrides the default when it is loaded after the
+'javaman init' line; undo it with 'javaman env restore' to rely on the
+default instead.
+
+Without arguments, prints the current default version.
+
+Examples:
+  javaman default 21        # Use the highest installed 21.x by default
+  javaman default lts       # Use the version aliased as 'lts'
+  javaman default           # Show the default version
+  javaman default --unset   # Clear the default version`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.GetConfig()
+
+		if defaultUnset {
+			if len(args) > 0 {
+				return fmt.Errorf("--unset does not take a version")
+			}
+			if cfg.Settings.Default == "" {
+				fmt.Println("No default version set")
+				return nil
+			}
+			cfg.Settings.Default = ""
+			if err := config.SaveConfig(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			fmt.Println("Default version cleared")
+			return nil
+		}
+
+		// 显示当前默认版本
+		if len(args) == 0 {
+			if cfg.Settings.Default == "" {
+				fmt.Println("No default version set")
+				return nil
+			}
+			version, _, err := resolve.Match(cfg.Settings.Default)
+			if err != nil {
+				return fmt.Errorf("default version %s: %w", cfg.Settings.Default, err)
+			}
+			if version == cfg.Settings.Default {
+				fmt.Println(version)
+			} else {
+				fmt.Printf("%s (currently %s)\n", cfg.Settings.Default, version)
+			}
+			return nil
+		}
+
+		version, jdkPath, err := resolve.Match(args[0])
+		if err != nil {
+			return err
+		}
+
+		// 验证JDK路径
+		if !env.IsValidJDKPath(jdkPath) {
+			return fmt.Errorf("invalid JDK path: %s", jdkPath)
+		}
+
+		cfg.Settings.Default = args[0]
+		if err := config.SaveConfig(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		if version == args[0] {
+			fmt.Printf("Default version set to %s\n", version)
+		} else {
+			fmt.Printf("Default version set to %s (currently %s)\n", args[0], version)
+		}
+		return nil
+	},
+}
+
+func init() {
+	defaultCmd.Flags().BoolVar(&defaultUnset, "unset", false, "clear the default version")
+	rootCmd.AddCommand(defaultCmd)
+}
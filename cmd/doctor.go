@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+
+	"javaman/internal/doctor"
+
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose problems with the Java environment",
+	Long: `Check the Java environment for common problems:
+
+  - JAVA_HOME pointing to a missing or broken JDK
+  - another java (e.g. /usr/bin/java) earlier on PATH than JAVA_HOME
+  - conflicting 'export JAVA_HOME=' lines in .bashrc, .zshrc, .profile
+  - configured JDKs whose directory is gone or that do not run
+  - default version and aliases referring to unknown versions
+
+Each finding is reported with a severity and a suggestion. With --fix,
+the safe cases are repaired: configuration entries for deleted JDKs and
+dangling default/alias settings are removed, and 'export JAVA_HOME=' lines
+pointing to a missing directory are commented out. Shell startup files are
+backed up first and can be restored with 'javaman env restore'. Other lines
+and environment variables are never changed.
+
+Exits with an error if problems of severity 'error' remain.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		findings := doctor.Run()
+		if len(findings) == 0 {
+			fmt.Println("No problems found")
+			return nil
+		}
+
+		errors, fixable, fixed := 0, 0, 0
+		for _, f := range findings {
+			fmt.Printf("%-9s %s\n", "["+string(f.Severity)+"]", f.Message)
+
+			if f.Fix != nil && doctorFix {
+				if err := f.Fix(); err != nil {
+					fmt.Printf("          fix failed: %v\n", err)
+				} else {
+					fmt.Println("          fixed")
+					fixed++
+					continue
+				}
+			} else if f.Fix != nil {
+				fixable++
+			}
+			fmt.Printf("          fix: %s\n", f.Suggestion)
+			if f.Severity == doctor.SeverityError {
+				errors++
+			}
+		}
+
+		if fixed > 0 {
+			// 配置变化后同步shim和toolchain配置
+			if err := syncInstallations(); err != nil {
+				return err
+			}
+		}
+
+		fmt.Println()
+		fmt.Printf("%d problem(s) found", len(findings))
+		if fixed > 0 {
+			fmt.Printf(", %d fixed", fixed)
+		}
+		if fixable > 0 {
+			fmt.Printf(", %d can be fixed with 'javaman doctor --fix'", fixable)
+		}
+		fmt.Println()
+
+		if errors > 0 {
+			return fmt.Errorf("%d error(s) remain", errors)
+		}
+		return nil
+	},
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "repair problems that can be fixed safely")
+	rootCmd.AddCommand(doctorCmd)
+}
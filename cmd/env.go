@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"javaman/internal/env"
+	"javaman/internal/resolve"
+	"javaman/internal/shell"
+	"javaman/internal/shim"
+
+	"github.com/spf13/cobra"
+)
+
+var envShell string
+
+var envCmd = &cobra.Command{
+	Use:   "env [version]",
+	Short: "Print shell commands that activate a JDK version",
+	Long: `Print export statements that set JAVA_HOME and PATH for the given
+JDK version. The output is meant to be evaluated by the current shell.
+
+Without a version, print the statements for the version selected by the
+usual rules (JAVAMAN_VERSION, project file, default version).
+Nothing is printed when no version is selected. The script printed by 'javaman init'
+runs this when a shell starts, so that new shells use the default
+version. When ~/.javaman/shims is on PATH, only JAVA_HOME is set and PATH
+is left to the shims.
+
+Examples:
+  eval "$(javaman env 17)"             # bash/zsh
+  javaman env 17 --shell fish | source # fish
+  eval "$(javaman env)"                # Apply the default version`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			sel, err := resolve.Resolve()
+			if errors.Is(err, resolve.ErrNoVersion) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			script, err := startupScript(envShell, sel.Path)
+			if err != nil {
+				return err
+			}
+			fmt.Print(script)
+			return nil
+		}
+
+		version, jdkPath, err := resolve.Match(args[0])
+		if err != nil {
+			return err
+		}
+
+		// 验证JDK路径
+		if !env.IsValidJDKPath(jdkPath) {
+			return fmt.Errorf("invalid JDK path: %s", jdkPath)
+		}
+
+		script, err := sessionScript(envShell, version, jdkPath)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(script)
+		return nil
+	},
+}
+
+func init() {
+	envCmd.Flags().StringVar(&envShell, "shell", "", "target shell (bash, zsh, fish, nu, pwsh); detected from $SHELL by default")
+	rootCmd.AddCommand(envCmd)
+}
+
+// sessionScript 生成在当前会话中切换到指定JDK的shell脚本
+func sessionScript(sh, version, jdkPath string) (string, error) {
+	if sh == "" {
+		sh = shell.Detect()
+	}
+
+	return shell.Exports(sh, []shell.Var{
+		{Name: resolve.VersionEnvVar, Value: version},
+		{Name: "JAVA_HOME", Value: jdkPath},
+		{Name: "PATH", Value: env.SessionPath(jdkPath)},
+	})
+}
+
+// startupScript 生成新会话启动时应用所选JDK的shell脚本
+// 不设置JAVAMAN_VERSION，以便项目版本文件和默认版本的变化仍然生效；
+// shim目录在PATH中时由shim选择JDK，不修改PATH
+func startupScript(sh, jdkPath string) (string, error) {
+	if sh == "" {
+		sh = shell.Detect()
+	}
+
+	vars := []shell.Var{{Name: "JAVA_HOME", Value: jdkPath}}
+	if !shim.OnPath() {
+		vars = append(vars, shell.Var{Name: "PATH", Value: env.SessionPath(jdkPath)})
+	}
+	return shell.Exports(sh, vars)
+}
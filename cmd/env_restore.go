@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"javaman/internal/backup"
+
+	"github.com/spf13/cobra"
+)
+
+var envRestoreList bool
+
+var envRestoreCmd = &cobra.Command{
+	Use:   "restore [timestamp]",
+	Short: "Restore files changed by javaman from a backup",
+	Long: `Restore the files changed by javaman: shell startup files and env files
+('javaman use --global'), Maven toolchains.xml and gradle.properties
+('javaman toolchains' and the automatic sync), and .java-version files
+('javaman local').
+
+Before javaman changes a file, it saves a copy under
+~/.javaman/backups/<timestamp> together with a manifest.json describing
+the change. This command puts the files back as they were before the
+given change (the latest one by default). Files that javaman created
+are removed.
+
+The current contents are backed up again before restoring, so a restore
+can itself be undone.
+
+Examples:
+  javaman env restore --list            # Show available backups
+  javaman env restore                   # Undo the latest change
+  javaman env restore 20240101-120000   # Undo a specific change`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if envRestoreList {
+			manifests, err := backup.List()
+			if err != nil {
+				return err
+			}
+			if len(manifests) == 0 {
+				fmt.Println("No backups found")
+				return nil
+			}
+			for _, m := range manifests {
+				fmt.Printf("%s  %s\n", m.Timestamp, m.Description)
+				for _, entry := range m.Files {
+					fmt.Printf("    %s (%s)\n", entry.Path, entry.Change)
+				}
+			}
+			return nil
+		}
+
+		timestamp := ""
+		if len(args) > 0 {
+			timestamp = args[0]
+		}
+		restored, session, err := backup.Restore(timestamp)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range restored.Files {
+			if entry.Existed {
+				fmt.Printf("Restored %s\n", entry.Path)
+			} else {
+				fmt.Printf("Removed %s\n", entry.Path)
+			}
+		}
+		fmt.Printf("Restored backup %s\n", restored.Timestamp)
+		if session.Timestamp() != "" {
+			fmt.Printf("Previous contents saved as backup %s\n", session.Timestamp())
+		}
+		fmt.Println("Open a new terminal for the changes to take effect")
+		return nil
+	},
+}
+
+func init() {
+	envRestoreCmd.Flags().BoolVar(&envRestoreList, "list", false, "list available backups")
+	envCmd.AddCommand(envRestoreCmd)
+}
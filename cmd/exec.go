@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"javaman/internal/env"
+	"javaman/internal/resolve"
+
+	"github.com/spf13/cobra"
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec [version] -- [command] [args...]",
+	Short: "Run a command with a specific JDK",
+	Long: `Run a single command with JAVA_HOME set to the given JDK and its bin
+directory first on PATH. Neither the current shell nor the global
+setting is changed.
+
+The version can be an ID, an alias or a version range, like 'use'.
+The command's exit status is returned, and signals such as Ctrl-C are
+delivered to the command.
+
+Examples:
+  javaman exec 8 -- mvn verify
+  javaman exec temurin-17 -- java -version
+  javaman exec lts -- ./gradlew build`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// 版本之后不再解析参数，"--"会原样保留
+		command := args[1:]
+		if command[0] == "--" {
+			command = command[1:]
+		}
+		if len(command) == 0 {
+			return fmt.Errorf("requires a command to run")
+		}
+
+		version, jdkPath, err := resolve.Match(args[0])
+		if err != nil {
+			return err
+		}
+		if !env.IsValidJDKPath(jdkPath) {
+			return fmt.Errorf("invalid JDK path: %s", jdkPath)
+		}
+
+		// 子进程中的shim也使用同一个JDK
+		environ := env.SetVar(env.Environ(jdkPath), resolve.VersionEnvVar, version)
+
+		// 在切换后的PATH中查找命令，使java、javac等指向所选JDK
+		if err := os.Setenv("PATH", env.SessionPath(jdkPath)); err != nil {
+			return err
+		}
+		program, err := exec.LookPath(command[0])
+		if err != nil {
+			// 保留原始错误，区分命令不存在、没有执行权限等情况
+			return fmt.Errorf("cannot run %s with %s: %w", command[0], version, err)
+		}
+
+		return env.Exec(program, command[1:], environ)
+	},
+}
+
+func init() {
+	// 版本之后的参数都属于要执行的命令，不作为javaman的参数解析
+	execCmd.Flags().SetInterspersed(false)
+	rootCmd.AddCommand(execCmd)
+}
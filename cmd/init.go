@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"javaman/internal/shell"
+
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init [shell]",
+	Short: "Print shell integration script",
+	Long: `Print a shell function that wraps javaman so that 'javaman use'
+changes JAVA_HOME and PATH in the current terminal session. When the
+shell starts, the script also applies the default version (see
+'javaman default') by running 'javaman env'.
+
+Supported shells: bash, zsh, fish, pwsh.
+If no shell is given, it is detected from $SHELL.
+
+Add one of the following lines to your shell startup file:
+  bash (~/.bashrc):                  eval "$(javaman init bash)"
+  zsh  (~/.zshrc):                   eval "$(javaman init zsh)"
+  fish (~/.config/fish/config.fish): javaman init fish | source
+  pwsh ($PROFILE):                   Invoke-Expression (& javaman init pwsh | Out-String)
+
+Nushell cannot evaluate generated code, use 'javaman use --global'
+to write JAVA_HOME into env.nu instead.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sh := shell.Detect()
+		if len(args) == 1 {
+			sh = args[0]
+		}
+
+		script, err := shell.InitScript(sh)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(script)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
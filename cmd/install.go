@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"javaman/internal/config"
+	"javaman/internal/install"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	installCatalog string
+	installRefresh bool
+	installArchive string
+	installSHA1    bool
+)
+
+var installCmd = &cobra.Command{
+	Use:   "install [vendor@version]",
+	Short: "Download and install a JDK",
+	Long: `Download a JDK archive, verify its checksum, unpack it into
+~/.javaman/jdks/<id> and add it to javaman.
+
+Archives are looked up from the distribution's own API (see 'javaman
+ls-remote'), or from a catalog when one is configured. The catalog is a
+JSON file listing available archives:
+
+  {"jdks": [{"vendor": "temurin", "version": "21.0.1+12",
+             "os": "linux", "arch": "x64", "archive_type": "tar.gz",
+             "url": "OpenJDK21U-jdk_x64_linux_hotspot_21.0.1_12.tar.gz",
+             "sha256": "..."}]}
+
+Relative URLs are resolved against the catalog URL, so a directory on a
+file share can serve as a mirror (file:///mnt/jdks/catalog.json).
+Set the catalog with 'settings.catalog_url' in the config file or --catalog.
+
+Archives that only publish a SHA-1 checksum (for example Liberica) are
+refused unless --allow-sha1 is given, because SHA-1 cannot reliably
+detect a tampered download.
+
+With --archive, a local tar.gz or zip archive is unpacked instead of
+downloading one. The ID is derived from the JDK's release file.
+
+Examples:
+  javaman install temurin@21        # Latest Temurin 21
+  javaman install zulu@17.0         # Latest Zulu 17.0.x
+  javaman install 21                # Latest 21 from any vendor
+  javaman install --archive ./OpenJDK21U-jdk_x64_linux.tar.gz`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if installArchive != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if installArchive != "" {
+			fmt.Printf("Installing %s\n", installArchive)
+			id, jdkPath, err := install.InstallArchive(installArchive, os.Stdout)
+			if err != nil {
+				return fmt.Errorf("failed to install %s: %w", installArchive, err)
+			}
+			return registerInstalled(id, jdkPath)
+		}
+
+		vendor, _, err := install.ParseRequest(args[0])
+		if err != nil {
+			return err
+		}
+		results, err := fetchRemote(installCatalog, vendor, installRefresh)
+		if err != nil {
+			return err
+		}
+		var releases []install.Release
+		for _, result := range results {
+			releases = append(releases, result.releases...)
+		}
+		rel, err := install.Find(releases, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Installing %s %s\n", rel.Vendor, rel.Version)
+		id, jdkPath, err := install.Install(rel, installSHA1, os.Stdout)
+		if errors.Is(err, install.ErrWeakChecksum) {
+			return fmt.Errorf("%w; use --allow-sha1 to install it anyway", err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to install %s %s: %w", rel.Vendor, rel.Version, err)
+		}
+		return registerInstalled(id, jdkPath)
+	},
+}
+
+// registerInstalled 将安装的JDK添加到配置
+func registerInstalled(id, jdkPath string) error {
+	// 添加到配置
+	if err := config.AddVersion(id, jdkPath); err != nil {
+		return fmt.Errorf("failed to add version: %w", err)
+	}
+
+	// 同步shim和toolchain配置
+	if err := syncInstallations(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Installed JDK version %s\n", id)
+	fmt.Printf("Path: %s\n", jdkPath)
+	return nil
+}
+
+func init() {
+	installCmd.Flags().StringVar(&installCatalog, "catalog", "", "catalog URL (http, https or file), overrides settings.catalog_url")
+	installCmd.Flags().BoolVar(&installRefresh, "refresh", false, "ignore cached metadata")
+	installCmd.Flags().BoolVar(&installSHA1, "allow-sha1", false, "allow archives that are only verified with SHA-1")
+	installCmd.Flags().StringVar(&installArchive, "archive", "", "install from a local tar.gz or zip archive")
+	rootCmd.AddCommand(installCmd)
+}
@@ -3,9 +3,13 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 
 	"javaman/internal/config"
+	"javaman/internal/detect"
 	"javaman/internal/env"
+	javaversion "javaman/internal/version"
 
 	"github.com/spf13/cobra"
 )
@@ -17,7 +21,8 @@ var listCmd = &cobra.Command{
 	Long: `List all JDK versions that are currently managed by javaman.
 
 This command shows:
-- All installed JDK versions and their paths
+- All installed JDK versions and their paths, grouped by feature release
+- Vendor, full version, architecture and build date of each JDK
 - Currently active version (marked with *)
 - Default version (if set)
 - Version aliases (if any)`,
@@ -37,24 +42,45 @@ This command shows:
 		fmt.Println("Available JDK versions:")
 		fmt.Println("---------------------")
 
-		// 获取所有版本并排序
-		versions := make([]string, 0, len(cfg.Versions))
+		// 按特性版本（主版本号）分组
+		groups := make(map[string][]string)
 		for version := range cfg.Versions {
-			versions = append(versions, version)
+			feature := featureRelease(version)
+			groups[feature] = append(groups[feature], version)
 		}
-		sort.Strings(versions)
+		features := make([]string, 0, len(groups))
+		for feature := range groups {
+			features = append(features, feature)
+		}
+		sort.Slice(features, func(i, j int) bool {
+			return javaversion.CompareStrings(features[i], features[j]) < 0
+		})
 
 		// 显示所有版本
-		for _, version := range versions {
-			path := cfg.Versions[version]
-			prefix := "  "
-			if path == currentJavaHome {
-				prefix = "* "
-			}
-			if version == cfg.Settings.Default {
-				prefix = prefix + "[Default] "
+		for _, feature := range features {
+			versions := groups[feature]
+			sort.Slice(versions, func(i, j int) bool {
+				c := javaversion.CompareStrings(config.VersionOf(versions[i]), config.VersionOf(versions[j]))
+				return c < 0 || (c == 0 && versions[i] < versions[j])
+			})
+
+			fmt.Printf("JDK %s:\n", feature)
+			for _, version := range versions {
+				path := cfg.Versions[version]
+				prefix := "  "
+				if path == currentJavaHome {
+					prefix = "* "
+				}
+				if version == cfg.Settings.Default {
+					prefix = prefix + "[Default] "
+				}
+				fmt.Printf("  %s%-18s -> %s\n", prefix, version, path)
+				if info, ok := config.GetInfo(version); ok {
+					if summary := info.Summary(); summary != "" {
+						fmt.Printf("  %s%-18s    %s\n", strings.Repeat(" ", len(prefix)), "", summary)
+					}
+				}
 			}
-			fmt.Printf("%s%-10s -> %s\n", prefix, version, path)
 		}
 
 		// 显示别名
@@ -97,3 +123,11 @@ This command shows:
 func init() {
 	rootCmd.AddCommand(listCmd)
 }
+
+// featureRelease 返回JDK的特性版本号（主版本号）
+func featureRelease(id string) string {
+	if v, err := javaversion.Parse(config.VersionOf(id)); err == nil {
+		return strconv.Itoa(v.Feature())
+	}
+	return detect.NormalizeVersion(config.VersionOf(id))
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"javaman/internal/backup"
+	"javaman/internal/resolve"
+
+	"github.com/spf13/cobra"
+)
+
+var localUnset bool
+
+var localCmd = &cobra.Command{
+	Use:   "local [version]",
+	Short: "Set the JDK version for the current directory",
+	Long: `Set the JDK version for the current directory by writing a
+.java-version file.
+
+javaman looks for the nearest version file in the current directory and
+its parents. Besides .java-version, it also reads the java entries of
+.sdkmanrc (java=17.0.9-tem), asdf's .tool-versions (java temurin-17.0.9)
+and jabba's .jvmrc (temurin@17). When several exist in one directory,
+they are checked in that order. A version found this way takes precedence
+over the default version, but not over 'javaman use' in the current shell.
+
+Without arguments, prints the version pinned for the current directory.
+
+Examples:
+  javaman local 11        # Pin JDK 11 for this directory
+  javaman local           # Show the pinned version and its file
+  javaman local --unset   # Remove .java-version from this directory`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		if localUnset {
+			file := filepath.Join(dir, resolve.ProjectFileName)
+			session := backup.New("local --unset " + dir)
+			defer session.Close()
+			if err := session.Remove(file, "project JDK version"); err != nil {
+				if os.IsNotExist(err) {
+					return fmt.Errorf("no %s in current directory", resolve.ProjectFileName)
+				}
+				return fmt.Errorf("failed to remove %s: %w", file, err)
+			}
+			if err := session.Close(); err != nil {
+				return err
+			}
+			fmt.Printf("Removed %s\n", file)
+			printBackup(session)
+			return nil
+		}
+
+		// 显示当前目录生效的项目版本
+		if len(args) == 0 {
+			file, version, err := resolve.FindProjectFile(dir)
+			if err != nil {
+				return err
+			}
+			if file == "" {
+				fmt.Println("No project version file found")
+				return nil
+			}
+			fmt.Printf("%s (set by %s)\n", version, file)
+			return nil
+		}
+
+		// 检查版本是否存在
+		if _, _, err := resolve.Match(args[0]); err != nil {
+			return err
+		}
+
+		session := backup.New("local " + args[0] + " " + dir)
+		defer session.Close()
+		file, err := resolve.WriteProjectFile(session, dir, args[0])
+		if err != nil {
+			return err
+		}
+		if err := session.Close(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Set JDK version %s for %s\n", args[0], dir)
+		fmt.Printf("Written to: %s\n", file)
+		printBackup(session)
+		return nil
+	},
+}
+
+func init() {
+	localCmd.Flags().BoolVar(&localUnset, "unset", false, "remove the .java-version file from the current directory")
+	rootCmd.AddCommand(localCmd)
+}
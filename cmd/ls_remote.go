@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"javaman/internal/config"
+	"javaman/internal/install"
+	"javaman/internal/provider"
+	javaversion "javaman/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	remoteCatalog string
+	remoteRefresh bool
+)
+
+var lsRemoteCmd = &cobra.Command{
+	Use:   "ls-remote [vendor@version]",
+	Short: "List JDKs available for download",
+	Long: `List JDK releases available for the current OS and architecture.
+
+Metadata is fetched from the distribution's own API (Temurin, Zulu,
+Corretto, Liberica, Microsoft, GraalVM CE) and cached for 24 hours in
+~/.javaman/cache/remote. If a distribution cannot be reached, the
+cached list is used even when it is out of date.
+
+When a catalog is configured (settings.catalog_url or --catalog), only
+the catalog is used.
+
+Examples:
+  javaman ls-remote                 # All distributions
+  javaman ls-remote temurin         # Temurin only
+  javaman ls-remote zulu@17         # Zulu 17.x
+  javaman ls-remote 21+             # 21 or newer from any vendor
+  javaman ls-remote --refresh       # Ignore the cache`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		request := "*"
+		if len(args) > 0 {
+			request = args[0]
+			if _, ok := provider.Get(strings.ToLower(request)); ok {
+				request += "@*"
+			}
+		}
+		vendor, spec, err := install.ParseRequest(request)
+		if err != nil {
+			return err
+		}
+
+		results, err := fetchRemote(remoteCatalog, vendor, remoteRefresh)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, result := range results {
+			var matched []install.Release
+			for _, rel := range result.releases {
+				if rel.Matches(vendor, spec) {
+					matched = append(matched, rel)
+				}
+			}
+			if len(matched) == 0 {
+				continue
+			}
+			found = true
+
+			fmt.Printf("%s%s\n", result.name, cacheNote(result))
+			for _, rel := range matched {
+				line := fmt.Sprintf("  %-22s %-7s", remoteLabel(result.name, &rel), rel.ArchiveType)
+				if id := installedAs(&rel); id != "" {
+					line += " installed as " + id
+				}
+				fmt.Println(strings.TrimRight(line, " "))
+			}
+		}
+		if !found {
+			return fmt.Errorf("no JDK matching %s found for %s/%s", request, install.CurrentOS(), install.CurrentArch())
+		}
+		return nil
+	},
+}
+
+// remoteResult 单个发行版来源的发布包列表
+type remoteResult struct {
+	name      string
+	releases  []install.Release
+	fetchedAt time.Time
+	stale     bool
+}
+
+// fetchRemote 获取可下载的发布包
+// 配置了发布包目录时只使用目录，否则使用内置的发行版来源；vendor不为空时只获取该发行商
+func fetchRemote(catalogURL, vendor string, refresh bool) ([]remoteResult, error) {
+	if catalogURL == "" {
+		catalogURL = config.GetConfig().Settings.CatalogURL
+	}
+
+	var providers []provider.Provider
+	switch {
+	case catalogURL != "":
+		providers = []provider.Provider{provider.NewCatalog(catalogURL)}
+	case vendor != "":
+		p, ok := provider.Get(vendor)
+		if !ok {
+			return nil, fmt.Errorf("no distribution provider for vendor %s, available: %s", vendor, strings.Join(provider.Names(), ", "))
+		}
+		providers = []provider.Provider{p}
+	default:
+		providers = provider.All()
+	}
+
+	var results []remoteResult
+	var errs []string
+	for _, p := range providers {
+		result := provider.Fetch(p, install.CurrentOS(), install.CurrentArch(), refresh)
+		if result.Err != nil {
+			if !result.Stale {
+				errs = append(errs, result.Err.Error())
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v, using cached list\n", result.Err)
+		}
+		results = append(results, remoteResult{
+			name:      p.Name(),
+			releases:  result.Releases,
+			fetchedAt: result.FetchedAt,
+			stale:     result.Stale,
+		})
+	}
+
+	if len(results) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("failed to fetch JDK list: %s", strings.Join(errs, "; "))
+	}
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", e)
+	}
+	return results, nil
+}
+
+// cacheNote 返回缓存时间说明
+func cacheNote(result remoteResult) string {
+	age := time.Since(result.fetchedAt)
+	switch {
+	case result.stale:
+		return fmt.Sprintf(" (offline, cached %s ago)", age.Round(time.Minute))
+	case age >= time.Minute:
+		return fmt.Sprintf(" (cached %s ago)", age.Round(time.Minute))
+	}
+	return ""
+}
+
+// remoteLabel 返回发布包的显示名称，发布包目录中包含多个发行商，需要带上发行商
+func remoteLabel(source string, rel *install.Release) string {
+	if source == rel.Vendor {
+		return rel.Version
+	}
+	return rel.Vendor + "@" + rel.Version
+}
+
+// installedAs 返回已安装的相同版本JDK标识，未安装时返回空字符串
+func installedAs(rel *install.Release) string {
+	if _, ok := config.GetVersions()[rel.ID()]; ok {
+		return rel.ID()
+	}
+	// 只比较版本号，忽略构建号（1.8.0_392与8.0.392+8视为相同）
+	core, _, _ := strings.Cut(rel.Version, "+")
+	remote, err := javaversion.Parse(core)
+	if err != nil {
+		return ""
+	}
+	found := ""
+	for id := range config.GetVersions() {
+		info, ok := config.GetInfo(id)
+		if !ok || info.Vendor != rel.Vendor {
+			continue
+		}
+		local, err := javaversion.Parse(info.JavaVersion)
+		if err != nil {
+			continue
+		}
+		local.Build = 0
+		if javaversion.Compare(local, remote) == 0 && (found == "" || id < found) {
+			found = id
+		}
+	}
+	return found
+}
+
+func init() {
+	lsRemoteCmd.Flags().StringVar(&remoteCatalog, "catalog", "", "catalog URL (http, https or file), overrides settings.catalog_url")
+	lsRemoteCmd.Flags().BoolVar(&remoteRefresh, "refresh", false, "ignore cached metadata")
+	rootCmd.AddCommand(lsRemoteCmd)
+}
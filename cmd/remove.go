@@ -37,30 +37,8 @@ Examples:
 			return fmt.Errorf("version %s not found", version)
 		}
 
-		// 检查是否是当前使用的版本
-		currentJavaHome, err := env.GetJavaHome()
-		if err == nil && currentJavaHome == path {
-			fmt.Printf("Warning: Removing currently active version %s\n", version)
-			fmt.Println("You should switch to another version after this operation.")
-		}
-
-		// 检查是否是默认版本
-		if cfg.Settings.Default == version {
-			cfg.Settings.Default = ""
-			fmt.Println("Note: Removed version was the default version.")
-		}
-
-		// 检查是否有别名指向此版本
-		for alias, target := range cfg.Aliases {
-			if target == version {
-				delete(cfg.Aliases, alias)
-				fmt.Printf("Removed alias '%s' that pointed to version %s\n", alias, version)
-			}
-		}
-
-		// 删除版本
-		if err := config.RemoveVersion(version); err != nil {
-			return fmt.Errorf("failed to remove version: %w", err)
+		if err := unregisterVersion(version, path); err != nil {
+			return err
 		}
 
 		fmt.Printf("Successfully removed JDK version %s\n", version)
@@ -68,6 +46,33 @@ Examples:
 	},
 }
 
+// unregisterVersion 从配置中删除JDK版本，同时清理默认版本、别名、shim和toolchain配置
+func unregisterVersion(version, path string) error {
+	// 检查是否是当前使用的版本
+	currentJavaHome, err := env.GetJavaHome()
+	if err == nil && currentJavaHome == path {
+		fmt.Printf("Warning: Removing currently active version %s\n", version)
+		fmt.Println("You should switch to another version after this operation.")
+	}
+
+	// 清除默认版本和指向此版本的别名
+	aliases, wasDefault := config.RemoveReferences(version)
+	if wasDefault {
+		fmt.Println("Note: Removed version was the default version.")
+	}
+	for _, alias := range aliases {
+		fmt.Printf("Removed alias '%s' that pointed to version %s\n", alias, version)
+	}
+
+	// 删除版本
+	if err := config.RemoveVersion(version); err != nil {
+		return fmt.Errorf("failed to remove version: %w", err)
+	}
+
+	// 同步shim和toolchain配置
+	return syncInstallations()
+}
+
 func init() {
 	rootCmd.AddCommand(removeCmd)
 }
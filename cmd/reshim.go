@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"javaman/internal/shim"
+
+	"github.com/spf13/cobra"
+)
+
+var reshimCmd = &cobra.Command{
+	Use:   "reshim",
+	Short: "Regenerate shims for all JDK tools",
+	Long: `Regenerate the shim directory (~/.javaman/shims) with one shim for every
+executable found in the bin directories of the managed JDKs.
+
+Each shim runs the tool from the JDK selected at execution time:
+1. The JAVAMAN_VERSION environment variable (set by 'javaman use')
+2. The nearest .java-version file (see 'javaman local')
+3. The default version
+
+Add the shim directory to the front of your PATH to use them.
+Shims are refreshed automatically by 'javaman add' and 'javaman remove'
+once the directory exists.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tools, err := shim.Sync(jdkPaths())
+		if err != nil {
+			return fmt.Errorf("failed to regenerate shims: %w", err)
+		}
+
+		dir, err := shim.Dir()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Generated %d shims in %s\n", len(tools), dir)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reshimCmd)
+}
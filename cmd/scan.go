@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+
+	"javaman/internal/config"
+	"javaman/internal/scan"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanApply bool
+	scanPrune bool
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Detect installed JDKs and compare them with the configuration",
+	Long: `Run JDK detection again and show how the result differs from the
+configuration:
+
+  +  new JDKs that are not managed yet (e.g. installed with apt later)
+  ~  JDKs whose version changed in place, or that moved to a new directory
+  -  configured JDKs whose directory no longer exists
+
+Besides the system JDK directories, JDKs installed by SDKMAN!, asdf,
+jabba, IntelliJ IDEA (~/.jdks), Gradle (~/.gradle/jdks), Homebrew,
+Nix and Debian's update-alternatives are detected. Additional roots
+can be listed in 'settings.scan_paths'; they are searched up to
+'settings.scan_depth' levels deep (default 2).
+
+Nothing is changed unless --apply is given. --apply adds new JDKs and
+updates changed ones under their existing IDs. Missing JDKs are only
+removed with --prune (which implies --apply). JDKs added manually with
+'javaman add' are matched by path and kept as they are, and aliases are
+never changed.
+
+Examples:
+  javaman scan                 # Show the differences
+  javaman scan --apply         # Add new and update changed JDKs
+  javaman scan --prune         # Also remove missing JDKs`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := scan.Compute()
+		if err != nil {
+			return fmt.Errorf("failed to detect JDKs: %w", err)
+		}
+		if plan.Empty() {
+			fmt.Println("Configuration is up to date")
+			return nil
+		}
+
+		for _, e := range plan.New {
+			fmt.Printf("+ %-20s %s\n", e.ID, e.Path)
+			if e.NewInfo != "" {
+				fmt.Printf("  %-20s %s\n", "", e.NewInfo)
+			}
+		}
+		for _, e := range plan.Changed {
+			if e.OldPath != "" {
+				fmt.Printf("~ %-20s %s -> %s\n", e.ID, e.OldPath, e.Path)
+			} else {
+				fmt.Printf("~ %-20s %s\n", e.ID, e.Path)
+				fmt.Printf("  %-20s %s -> %s\n", "", orUnknown(e.OldInfo), e.NewInfo)
+			}
+		}
+		for _, e := range plan.Missing {
+			fmt.Printf("- %-20s %s (missing)\n", e.ID, e.Path)
+		}
+
+		apply := scanApply || scanPrune
+		if !apply {
+			fmt.Println("\nRun 'javaman scan --apply' to update the configuration, or 'javaman scan --prune' to also remove missing JDKs")
+			return nil
+		}
+
+		if err := scan.Apply(plan, scanPrune); err != nil {
+			return fmt.Errorf("failed to update configuration: %w", err)
+		}
+		if err := syncInstallations(); err != nil {
+			return err
+		}
+
+		fmt.Printf("\nAdded %d, updated %d", len(plan.New), len(plan.Changed))
+		if scanPrune {
+			fmt.Printf(", removed %d", len(plan.Missing))
+		}
+		fmt.Println(" JDK(s)")
+
+		// 别名不会被自动修改，提示指向已删除版本的别名
+		if scanPrune {
+			for _, e := range plan.Missing {
+				for alias, target := range config.GetConfig().Aliases {
+					if target == e.ID {
+						fmt.Printf("Note: alias %s still points to removed version %s, change it with 'javaman alias set'\n", alias, e.ID)
+					}
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// orUnknown 为空字符串时返回"unknown"
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func init() {
+	scanCmd.Flags().BoolVar(&scanApply, "apply", false, "add new JDKs and update changed ones")
+	scanCmd.Flags().BoolVar(&scanPrune, "prune", false, "also remove JDKs whose directory no longer exists (implies --apply)")
+	rootCmd.AddCommand(scanCmd)
+}
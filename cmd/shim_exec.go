@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"javaman/internal/env"
+	"javaman/internal/resolve"
+	"javaman/internal/shim"
+
+	"github.com/spf13/cobra"
+)
+
+var shimExecCmd = &cobra.Command{
+	Use:                "shim-exec [tool] [args...]",
+	Short:              "Run a JDK tool from the selected JDK (used by shims)",
+	Hidden:             true,
+	SilenceUsage:       true,
+	DisableFlagParsing: true,
+	Args:               cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+
+		sel, err := resolve.Resolve()
+		if err != nil {
+			return err
+		}
+
+		toolPath := shim.ToolPath(sel.Path, tool)
+		if _, err := os.Stat(toolPath); err != nil {
+			return fmt.Errorf("%s is not available in JDK %s (%s)", tool, sel.Version, sel.Path)
+		}
+
+		return env.Exec(toolPath, args[1:], env.Environ(sel.Path))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shimExecCmd)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"javaman/internal/backup"
+	"javaman/internal/config"
+	"javaman/internal/shim"
+	"javaman/internal/toolchains"
+)
+
+// jdkPaths 返回配置中所有JDK的路径
+func jdkPaths() []string {
+	versions := config.GetVersions()
+	paths := make([]string, 0, len(versions))
+	for _, path := range versions {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// syncInstallations 在JDK列表变化后同步shim和已启用的toolchain配置
+// 只更新用户已经启用过的部分：shim目录已存在、toolchains.xml或gradle.properties中有javaman的标记注释
+// 修改的toolchain文件会被备份，可以通过javaman env restore撤销
+func syncInstallations() error {
+	if shim.Exists() {
+		if _, err := shim.Sync(jdkPaths()); err != nil {
+			return fmt.Errorf("failed to regenerate shims: %w", err)
+		}
+	}
+
+	session := backup.New("sync toolchains")
+	defer session.Close()
+
+	if file, err := toolchains.MavenFile(); err == nil && toolchains.HasMavenToolchains(file) {
+		if _, err := toolchains.SyncMaven(session, file, toolchainJDKs()); err != nil {
+			return fmt.Errorf("failed to update Maven toolchains: %w", err)
+		}
+	}
+
+	if file, err := toolchains.GradleFile(); err == nil && toolchains.HasGradlePaths(file) {
+		if _, err := toolchains.SyncGradle(session, file, toolchainJDKs()); err != nil {
+			return fmt.Errorf("failed to update Gradle properties: %w", err)
+		}
+	}
+
+	return session.Close()
+}
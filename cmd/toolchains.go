@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"javaman/internal/backup"
+	"javaman/internal/config"
+	"javaman/internal/toolchains"
+
+	"github.com/spf13/cobra"
+)
+
+var toolchainsFile string
+
+var toolchainsCmd = &cobra.Command{
+	Use:   "toolchains",
+	Short: "Register managed JDKs with build tool toolchains",
+	Long: `Register the JDKs managed by javaman with the toolchain support of
+build tools, so that they use the installed JDKs instead of requiring
+manual configuration or downloading their own.`,
+}
+
+var toolchainsMavenCmd = &cobra.Command{
+	Use:   "maven",
+	Short: "Sync Maven ~/.m2/toolchains.xml with managed JDKs",
+	Long: `Create or update Maven's toolchains.xml with one <toolchain type="jdk">
+entry per JDK managed by javaman.
+
+Entries created by javaman are marked with an id of the form
+'javaman:<version>'. Other entries in the file are kept as they are,
+and javaman entries for JDKs that are no longer managed are removed.
+
+Examples:
+  javaman toolchains maven
+  javaman toolchains maven --file ./toolchains.xml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := toolchainsFile
+		if file == "" {
+			var err error
+			if file, err = toolchains.MavenFile(); err != nil {
+				return err
+			}
+		}
+
+		session := backup.New("toolchains maven " + file)
+		defer session.Close()
+		changes, err := toolchains.SyncMaven(session, file, toolchainJDKs())
+		if err != nil {
+			return fmt.Errorf("failed to update Maven toolchains: %w", err)
+		}
+		if err := session.Close(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Updated %s\n", file)
+		printToolchainChanges(changes)
+		printBackup(session)
+		return nil
+	},
+}
+
+var toolchainsGradleCmd = &cobra.Command{
+	Use:   "gradle",
+	Short: "Register managed JDKs in Gradle's gradle.properties",
+	Long: `Write the paths of all JDKs managed by javaman to the
+org.gradle.java.installations.paths property in the user-level
+gradle.properties ($GRADLE_USER_HOME or ~/.gradle), so that Gradle
+toolchains find them instead of downloading their own.
+
+Other properties and paths you added yourself are left unchanged; the
+paths written by javaman are recorded in a comment above the property.
+Once javaman has written paths, 'javaman add' and 'javaman remove' keep
+them in sync.
+
+Examples:
+  javaman toolchains gradle
+  javaman toolchains gradle --file ./gradle.properties`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file := toolchainsFile
+		if file == "" {
+			var err error
+			if file, err = toolchains.GradleFile(); err != nil {
+				return err
+			}
+		}
+
+		session := backup.New("toolchains gradle " + file)
+		defer session.Close()
+		changes, err := toolchains.SyncGradle(session, file, toolchainJDKs())
+		if err != nil {
+			return fmt.Errorf("failed to update Gradle properties: %w", err)
+		}
+		if err := session.Close(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Updated %s\n", file)
+		printToolchainChanges(changes)
+		printBackup(session)
+		return nil
+	},
+}
+
+func init() {
+	toolchainsMavenCmd.Flags().StringVar(&toolchainsFile, "file", "", "path to toolchains.xml (default ~/.m2/toolchains.xml)")
+	toolchainsGradleCmd.Flags().StringVar(&toolchainsFile, "file", "", "path to gradle.properties (default ~/.gradle/gradle.properties)")
+	toolchainsCmd.AddCommand(toolchainsMavenCmd)
+	toolchainsCmd.AddCommand(toolchainsGradleCmd)
+	rootCmd.AddCommand(toolchainsCmd)
+}
+
+// toolchainJDKs 根据配置生成需要注册的JDK列表
+func toolchainJDKs() []toolchains.JDK {
+	versions := config.GetVersions()
+	jdks := make([]toolchains.JDK, 0, len(versions))
+	for version, path := range versions {
+		jdk := toolchains.JDK{ID: version, Path: path}
+		if info, ok := config.GetInfo(version); ok {
+			jdk.Version = info.JavaVersion
+			jdk.Vendor = info.Vendor
+		}
+		jdks = append(jdks, jdk)
+	}
+	return jdks
+}
+
+// printBackup 输出修改前内容的备份位置，没有修改文件时不输出
+func printBackup(session *backup.Session) {
+	if session.Timestamp() != "" {
+		fmt.Printf("Previous contents saved as backup %s, undo with 'javaman env restore %s'\n", session.Timestamp(), session.Timestamp())
+	}
+}
+
+// printToolchainChanges 输出toolchain同步结果
+func printToolchainChanges(changes *toolchains.Changes) {
+	if len(changes.Added) > 0 {
+		fmt.Printf("Added:   %s\n", strings.Join(changes.Added, ", "))
+	}
+	if len(changes.Removed) > 0 {
+		fmt.Printf("Removed: %s\n", strings.Join(changes.Removed, ", "))
+	}
+}
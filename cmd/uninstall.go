@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"javaman/internal/config"
+	"javaman/internal/install"
+
+	"github.com/spf13/cobra"
+)
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall [version]",
+	Short: "Delete a JDK installed by javaman",
+	Long: `Delete a JDK that was installed with 'javaman install' and remove it
+from javaman's management.
+
+Only JDKs under ~/.javaman/jdks that carry javaman's install manifest
+can be uninstalled. JDKs added with 'javaman add' (for example system
+JDKs under /usr/lib/jvm) are never deleted, use 'javaman remove' for them.
+
+Aliases pointing to the version, the default setting, shims and
+javaman's Maven and Gradle toolchain entries are cleaned up as well.
+
+Examples:
+  javaman uninstall temurin-21.0.1`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version := args[0]
+
+		// 只接受完整标识，避免版本范围误删其他JDK
+		path, exists := config.GetVersions()[version]
+		if !exists {
+			return fmt.Errorf("version %s not found. Use 'javaman list' to see available versions", version)
+		}
+
+		// 先确认是javaman安装的JDK，再修改配置
+		dir, err := install.InstallDir(path)
+		if err != nil {
+			return fmt.Errorf("cannot uninstall %s: %w", version, err)
+		}
+
+		if err := unregisterVersion(version, path); err != nil {
+			return err
+		}
+
+		if err := install.Uninstall(path); err != nil {
+			return fmt.Errorf("removed %s from configuration, but %w", version, err)
+		}
+
+		fmt.Printf("Successfully uninstalled JDK version %s\n", version)
+		fmt.Printf("Deleted %s\n", dir)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(uninstallCmd)
+}
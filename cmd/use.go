@@ -2,44 +2,63 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"javaman/internal/config"
 	"javaman/internal/env"
+	"javaman/internal/resolve"
+	"javaman/internal/shell"
 
 	"github.com/spf13/cobra"
 )
 
+var useGlobal bool
+
 var useCmd = &cobra.Command{
 	Use:   "use [version]",
 	Short: "Switch to specified JDK version",
 	Long: `Switch to a specific JDK version that is managed by javaman.
 
-This command will:
+With shell integration enabled (see 'javaman init'), this command
+changes JAVA_HOME and PATH in the current terminal session.
+
+With --global, it will additionally:
 1. Set JAVA_HOME to the specified JDK installation directory
 2. Update system PATH to include the JDK's bin directory
-3. Update the last used version in configuration
+in your shell startup files (or the registry on Windows), so that
+new terminals use this version as well. Besides ~/.bashrc, ~/.zshrc
+and ~/.profile, fish (~/.config/fish/conf.d/javaman.fish), nushell
+(env.nu) and PowerShell (Microsoft.PowerShell_profile.ps1) profiles
+are updated when the shell is in use or its config directory exists.
+
+The settings are written to ~/.javaman/env.sh (env.fish, env.nu,
+env.ps1), and each startup file only gets a block between
+'# >>> javaman >>>' and '# <<< javaman <<<' that loads it. Lines
+outside this block are never modified. Every file is backed up to
+~/.javaman/backups/<timestamp> before it is changed; undo the change
+with 'javaman env restore'.
+
+In both cases the last used version is updated in configuration.
 
 Examples:
-  javaman use 17    # Switch to JDK 17
-  javaman use 8     # Switch to JDK 8
-  javaman use lts   # Switch to version aliased as 'lts'
+  javaman use 17            # Switch to JDK 17 in the current shell
+  javaman use 8             # Switch to JDK 8 in the current shell
+  javaman use lts           # Switch to version aliased as 'lts'
+  javaman use --global 17   # Persist JDK 17 for new terminals
 
-Note: On Windows, this command requires administrator privileges.`,
-	Args: cobra.ExactArgs(1),
+Note: On Windows, --global requires administrator privileges.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		version := args[0]
+		sessionShell := os.Getenv(shell.SessionEnvVar)
+		if sessionShell == "" && !useGlobal {
+			return fmt.Errorf("shell integration is not enabled, see 'javaman init --help' or use 'javaman use --global %s'", args[0])
+		}
 
 		// 获取版本对应的路径
-		cfg := config.GetConfig()
-		jdkPath, exists := cfg.Versions[version]
-		if !exists {
-			// 检查是否是别名
-			if aliasPath, ok := cfg.Aliases[version]; ok {
-				jdkPath = cfg.Versions[aliasPath]
-				version = aliasPath
-			} else {
-				return fmt.Errorf("version %s not found. Use 'javaman list' to see available versions", version)
-			}
+		version, jdkPath, err := resolve.Match(args[0])
+		if err != nil {
+			return err
 		}
 
 		// 验证JDK路径
@@ -47,24 +66,46 @@ Note: On Windows, this command requires administrator privileges.`,
 			return fmt.Errorf("invalid JDK path: %s", jdkPath)
 		}
 
-		// 设置环境变量
-		if err := env.SetJavaHome(jdkPath); err != nil {
-			return fmt.Errorf("failed to set JAVA_HOME: %w", err)
+		// 生成当前会话的切换脚本
+		var script string
+		if sessionShell != "" {
+			script, err = sessionScript(sessionShell, version, jdkPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		// 持久化环境变量
+		if useGlobal {
+			if err := env.SetJavaHome(jdkPath); err != nil {
+				return fmt.Errorf("failed to set JAVA_HOME: %w", err)
+			}
 		}
 
 		// 更新last_used
+		cfg := config.GetConfig()
 		cfg.Settings.LastUsed = version
 		if err := config.SaveConfig(); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		fmt.Printf("Successfully switched to JDK %s\n", version)
-		fmt.Printf("JAVA_HOME: %s\n", jdkPath)
-		fmt.Printf("You may need to restart your terminal for changes to take effect.\n")
+		// 通过shell集成调用时，标准输出会被eval，提示信息写到标准错误
+		out := os.Stdout
+		if sessionShell != "" {
+			out = os.Stderr
+			fmt.Print(script)
+		}
+
+		fmt.Fprintf(out, "Successfully switched to JDK %s\n", version)
+		fmt.Fprintf(out, "JAVA_HOME: %s\n", jdkPath)
+		if useGlobal && sessionShell == "" {
+			fmt.Fprintf(out, "You may need to restart your terminal for changes to take effect.\n")
+		}
 		return nil
 	},
 }
 
 func init() {
+	useCmd.Flags().BoolVarP(&useGlobal, "global", "g", false, "persist the change in shell startup files (registry on Windows)")
 	rootCmd.AddCommand(useCmd)
 }
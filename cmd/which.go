@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"javaman/internal/resolve"
+	"javaman/internal/shim"
+
+	"github.com/spf13/cobra"
+)
+
+var whichVersion string
+
+var whichCmd = &cobra.Command{
+	Use:   "which [tool]",
+	Short: "Show the JDK binary that would run for a tool",
+	Long: `Print the absolute path of a JDK tool (java, javac, jarsigner, ...)
+in the JDK that would be selected, and the rule that selected it:
+env override (JAVAMAN_VERSION), project file or default.
+
+The path is written to standard output, the selection rule to standard
+error, so the command can be used in scripts: $(javaman which javac)
+
+Fails if the selected JDK does not ship the tool, e.g. javac in a JRE.
+
+Examples:
+  javaman which java
+  javaman which javac --version 17`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool := args[0]
+
+		var sel *resolve.Selection
+		if whichVersion != "" {
+			version, jdkPath, err := resolve.Match(whichVersion)
+			if err != nil {
+				return err
+			}
+			sel = &resolve.Selection{Version: version, Path: jdkPath, Source: resolve.SourceArgument, Origin: "--version"}
+		} else {
+			var err error
+			if sel, err = resolve.Resolve(); err != nil {
+				return err
+			}
+		}
+
+		toolPath, err := filepath.Abs(shim.ToolPath(sel.Path, tool))
+		if err != nil {
+			return err
+		}
+		if info, err := os.Stat(toolPath); err != nil || info.IsDir() {
+			return fmt.Errorf("%s is not available in JDK %s (%s)", tool, sel.Version, sel.Path)
+		}
+
+		fmt.Println(toolPath)
+		fmt.Fprintf(os.Stderr, "JDK %s selected by %s (%s)\n", sel.Version, sel.Source, sel.Origin)
+		return nil
+	},
+}
+
+func init() {
+	whichCmd.Flags().StringVar(&whichVersion, "version", "", "look up the tool in this JDK instead of the selected one")
+	rootCmd.AddCommand(whichCmd)
+}
@@ -0,0 +1,330 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"javaman/internal/config"
+)
+
+const (
+	backupsDirName = "backups"
+	manifestName   = "manifest.json"
+	timeFormat     = "20060102-150405"
+	// keepSessions 保留的备份数量，超出时删除最旧的备份
+	keepSessions = 20
+)
+
+// Manifest 一次修改操作的备份记录
+type Manifest struct {
+	Timestamp   string    `json:"timestamp"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	Files       []Entry   `json:"files"`
+}
+
+// Entry 一个被修改文件的备份记录
+type Entry struct {
+	Path    string      `json:"path"`             // 被修改的文件
+	Backup  string      `json:"backup,omitempty"` // 备份目录中的文件名，修改前文件不存在时为空
+	Existed bool        `json:"existed"`
+	Mode    os.FileMode `json:"mode,omitempty"`
+	Change  string      `json:"change"` // 修改内容说明
+}
+
+// Session 一次修改操作，第一次修改文件前创建备份目录，Close时写入备份记录
+type Session struct {
+	dir      string
+	manifest Manifest
+	saved    map[string]bool
+	closed   bool
+}
+
+// Dir 返回备份目录（~/.javaman/backups）
+func Dir() (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, backupsDirName), nil
+}
+
+// New 创建一次修改操作
+func New(description string) *Session {
+	return &Session{
+		manifest: Manifest{Description: description},
+		saved:    make(map[string]bool),
+	}
+}
+
+// WriteFile 备份文件的原始内容后写入新内容，内容没有变化时不写入
+func (s *Session) WriteFile(path string, content []byte, change string) error {
+	if old, err := os.ReadFile(path); err == nil && string(old) == string(content) {
+		return nil
+	}
+	if err := s.save(path, change); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return writeAtomic(path, content, 0)
+}
+
+// Remove 备份文件的原始内容后删除文件，文件不存在时返回os.ErrNotExist
+func (s *Session) Remove(path string, change string) error {
+	if _, err := os.Lstat(path); err != nil {
+		return err
+	}
+	if err := s.save(path, change); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return os.Remove(path)
+}
+
+// Close 写入备份记录并清理旧备份，没有修改任何文件时不创建备份
+// 重复调用Close不会产生影响
+func (s *Session) Close() error {
+	if s.dir == "" || s.closed {
+		return nil
+	}
+	s.closed = true
+	content, err := json.MarshalIndent(s.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, manifestName), content, 0644); err != nil {
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	return prune()
+}
+
+// Timestamp 返回备份的时间戳，没有修改任何文件时为空
+func (s *Session) Timestamp() string {
+	return s.manifest.Timestamp
+}
+
+// save 备份文件的原始内容，同一文件只备份一次
+func (s *Session) save(path string, change string) error {
+	if s.saved[path] {
+		return nil
+	}
+	if err := s.ensureDir(); err != nil {
+		return err
+	}
+
+	entry := Entry{Path: path, Change: change}
+	content, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			return statErr
+		}
+		entry.Existed = true
+		entry.Mode = info.Mode().Perm()
+		entry.Backup = strconv.Itoa(len(s.manifest.Files)) + "-" + filepath.Base(path)
+		if err := os.WriteFile(filepath.Join(s.dir, entry.Backup), content, 0600); err != nil {
+			return err
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	s.manifest.Files = append(s.manifest.Files, entry)
+	s.saved[path] = true
+	return nil
+}
+
+// ensureDir 创建以时间戳命名的备份目录，同一秒内有多次备份时添加序号
+func (s *Session) ensureDir() error {
+	if s.dir != "" {
+		return nil
+	}
+	root, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	base := now.Format(timeFormat)
+	for i := 1; ; i++ {
+		timestamp := base
+		if i > 1 {
+			timestamp = fmt.Sprintf("%s-%d", base, i)
+		}
+		dir := filepath.Join(root, timestamp)
+		err := os.Mkdir(dir, 0700)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		s.dir = dir
+		s.manifest.Timestamp = timestamp
+		s.manifest.CreatedAt = now
+		return nil
+	}
+}
+
+// List 返回所有备份记录，最新的在前
+func List() ([]Manifest, error) {
+	root, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []Manifest
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		m, err := readManifest(filepath.Join(root, entry.Name()))
+		if err != nil {
+			continue
+		}
+		manifests = append(manifests, *m)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].CreatedAt.After(manifests[j].CreatedAt)
+	})
+	return manifests, nil
+}
+
+// Restore 将文件恢复到指定备份时的状态，timestamp为空时使用最新的备份
+// 恢复前会先备份文件的当前内容，因此恢复操作本身也可以撤销
+func Restore(timestamp string) (*Manifest, *Session, error) {
+	manifests, err := List()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(manifests) == 0 {
+		return nil, nil, fmt.Errorf("no backups found")
+	}
+
+	var target *Manifest
+	if timestamp == "" {
+		target = &manifests[0]
+	} else {
+		for i := range manifests {
+			if manifests[i].Timestamp == timestamp {
+				target = &manifests[i]
+				break
+			}
+		}
+		if target == nil {
+			return nil, nil, fmt.Errorf("backup %s not found", timestamp)
+		}
+	}
+
+	root, err := Dir()
+	if err != nil {
+		return nil, nil, err
+	}
+	dir := filepath.Join(root, target.Timestamp)
+
+	session := New("restore " + target.Timestamp)
+	for _, entry := range target.Files {
+		if err := session.save(entry.Path, "restore"); err != nil {
+			return nil, nil, fmt.Errorf("failed to back up %s: %w", entry.Path, err)
+		}
+		if !entry.Existed {
+			// 文件由javaman创建，恢复时删除
+			if err := os.Remove(entry.Path); err != nil && !os.IsNotExist(err) {
+				return nil, nil, err
+			}
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Backup))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read backup of %s: %w", entry.Path, err)
+		}
+		if err := writeAtomic(entry.Path, content, entry.Mode); err != nil {
+			return nil, nil, fmt.Errorf("failed to restore %s: %w", entry.Path, err)
+		}
+	}
+	if err := session.Close(); err != nil {
+		return nil, nil, err
+	}
+	return target, session, nil
+}
+
+// readManifest 读取备份目录中的备份记录
+func readManifest(dir string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// prune 只保留最新的keepSessions个备份
+func prune() error {
+	manifests, err := List()
+	if err != nil || len(manifests) <= keepSessions {
+		return err
+	}
+	root, err := Dir()
+	if err != nil {
+		return err
+	}
+	for _, m := range manifests[keepSessions:] {
+		if err := os.RemoveAll(filepath.Join(root, m.Timestamp)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeAtomic 先写入临时文件再重命名，避免中断时留下不完整的文件
+// mode为0时保留已存在文件的权限；文件是符号链接时写入链接指向的文件
+func writeAtomic(file string, content []byte, mode os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(file); err == nil {
+		file = target
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	if mode == 0 {
+		mode = 0644
+		if info, err := os.Stat(file); err == nil {
+			mode = info.Mode().Perm()
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
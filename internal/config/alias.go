@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SetAlias 创建或修改别名
+// 目标可以是版本标识、版本范围（21+、temurin-21）或其他别名，范围在使用时才解析为具体版本
+func SetAlias(name, target string) error {
+	if err := validateAliasName(name); err != nil {
+		return err
+	}
+	if target == "" {
+		return fmt.Errorf("alias target must not be empty")
+	}
+
+	if config.Aliases == nil {
+		config.Aliases = make(map[string]string)
+	}
+	previous, existed := config.Aliases[name]
+	config.Aliases[name] = target
+
+	// 检查目标是否存在以及是否形成循环，失败时恢复原来的别名
+	if _, _, err := Lookup(name); err != nil {
+		if existed {
+			config.Aliases[name] = previous
+		} else {
+			delete(config.Aliases, name)
+		}
+		return err
+	}
+	return SaveConfig()
+}
+
+// RemoveAlias 删除别名，被其他别名引用时拒绝删除
+func RemoveAlias(name string) error {
+	if _, ok := config.Aliases[name]; !ok {
+		return fmt.Errorf("alias %s not found", name)
+	}
+	if users := aliasesTo(name); len(users) > 0 {
+		return fmt.Errorf("alias %s is used by alias %s, change or remove it first", name, strings.Join(users, ", "))
+	}
+	delete(config.Aliases, name)
+	return SaveConfig()
+}
+
+// ResolveAlias 沿别名链查找最终的目标，返回经过的别名链
+// 目标是已配置的版本标识时停止，别名之间形成循环时返回错误
+func ResolveAlias(name string) (target string, chain []string, err error) {
+	target = name
+	for {
+		next, ok := config.Aliases[target]
+		// 版本标识优先于同名别名，与Lookup一致
+		if _, isVersion := config.Versions[target]; isVersion || !ok {
+			return target, chain, nil
+		}
+		for _, seen := range chain {
+			if seen == target {
+				return "", nil, fmt.Errorf("alias cycle: %s -> %s", strings.Join(chain, " -> "), target)
+			}
+		}
+		chain = append(chain, target)
+		target = next
+	}
+}
+
+// validateAliasName 检查别名是否可以使用
+func validateAliasName(name string) error {
+	if name == "" {
+		return fmt.Errorf("alias name must not be empty")
+	}
+	if strings.ContainsAny(name, " \t\"'") || strings.Contains(name, keyDelimiter) {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	// 保存时键会被转换为小写，带大写字母的别名在下次运行时将无法找到
+	if name != strings.ToLower(name) {
+		return fmt.Errorf("alias name %q must be lowercase", name)
+	}
+	// 版本标识优先于别名，同名的别名永远不会生效
+	if _, ok := config.Versions[name]; ok {
+		return fmt.Errorf("%s is already a version ID", name)
+	}
+	return nil
+}
+
+// aliasesTo 返回直接指向name的别名
+func aliasesTo(name string) []string {
+	var users []string
+	for alias, target := range config.Aliases {
+		if target == name {
+			users = append(users, alias)
+		}
+	}
+	sort.Strings(users)
+	return users
+}
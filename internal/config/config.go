@@ -4,69 +4,85 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"javaman/internal/detect"
+	"javaman/internal/version"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Versions map[string]string `mapstructure:"versions"`
-	Settings ConfigSettings    `mapstructure:"settings"`
-	Aliases  map[string]string `mapstructure:"aliases"`
+	Versions map[string]string         `mapstructure:"versions"`
+	Settings ConfigSettings            `mapstructure:"settings"`
+	Aliases  map[string]string         `mapstructure:"aliases"`
+	JDKs     map[string]detect.JDKInfo `mapstructure:"jdks"`
 }
 
 type ConfigSettings struct {
-	Default  string `mapstructure:"default"`
-	LastUsed string `mapstructure:"last_used"`
+	Default    string   `mapstructure:"default"`
+	LastUsed   string   `mapstructure:"last_used"`
+	CatalogURL string   `mapstructure:"catalog_url"`
+	ScanPaths  []string `mapstructure:"scan_paths"` // 额外的JDK检测目录
+	ScanDepth  int      `mapstructure:"scan_depth"` // 在scan_paths中向下查找的最大层数
 }
 
 const (
 	configFileName = "config"
 	configFileType = "toml"
 	configDirName  = ".javaman"
+
+	cacheDirName     = "cache"
+	jdkCacheFileName = "jdks.json"
 )
 
+// keyDelimiter viper键分隔符，版本号中包含"."，因此不能使用默认分隔符
+const keyDelimiter = "::"
+
 var (
 	config *Config
+	store  = viper.NewWithOptions(viper.KeyDelimiter(keyDelimiter))
 )
 
 // Initialize 初始化配置并自动检测JDK
 func Initialize() error {
-	// 获取用户主目录
-	homeDir, err := os.UserHomeDir()
+	// 获取配置目录
+	configDir, err := Dir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
 	// 创建配置目录
-	configDir := filepath.Join(homeDir, configDirName)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	// JDK探测结果缓存在~/.javaman/cache/jdks.json
+	detect.SetCacheFile(filepath.Join(configDir, cacheDirName, jdkCacheFileName))
+
 	// 设置配置文件路径
 	configFile := filepath.Join(configDir, configFileName+"."+configFileType)
 
-	// 设置viper配置
-	viper.SetConfigName(configFileName)
-	viper.SetConfigType(configFileType)
-	viper.AddConfigPath(configDir)
+	// 设置配置文件
+	store.SetConfigName(configFileName)
+	store.SetConfigType(configFileType)
+	store.AddConfigPath(configDir)
 	if _, err := os.Stat(configFile); os.IsNotExist(err) {
 		// 如果配置文件不存在，创建新的配置实例
 		config = &Config{
 			Versions: make(map[string]string),
 			Settings: ConfigSettings{},
 			Aliases:  make(map[string]string),
+			JDKs:     make(map[string]detect.JDKInfo),
 		}
 
 		// 先创建空的配置文件
-		if err := viper.SafeWriteConfig(); err != nil {
+		if err := store.SafeWriteConfig(); err != nil {
 			return fmt.Errorf("failed to create config file: %w", err)
 		}
 
 		// 自动检测并添加JDK
-		detected, detectErr := detect.DetectJDKs()
+		detected, detectErr := detect.DetectJDKs(DetectOptions())
 		if detectErr != nil {
 			return fmt.Errorf("failed to detect JDKs: %w", detectErr)
 		}
@@ -76,9 +92,11 @@ func Initialize() error {
 			config.Versions[version] = path
 		}
 
+		fillMetadata()
+
 		// 如果有版本被检测到，设置最新版本为默认版本
 		if len(config.Versions) > 0 {
-			if latestVer, _, err := detect.GetLatestJDK(); err == nil {
+			if latestVer, _, err := detect.GetLatestJDK(detected); err == nil {
 				config.Settings.Default = latestVer
 			}
 		}
@@ -88,18 +106,22 @@ func Initialize() error {
 		}
 	} else if err == nil {
 		// 如果配置文件存在，读取配置
-		if err := viper.ReadInConfig(); err != nil {
+		if err := store.ReadInConfig(); err != nil {
 			return fmt.Errorf("failed to read config file: %w", err)
 		}
 
 		config = &Config{}
-		if err := viper.Unmarshal(config); err != nil {
+		if err := store.Unmarshal(config); err != nil {
 			return fmt.Errorf("failed to unmarshal config: %w", err)
 		}
 
+		if config.Versions == nil {
+			config.Versions = make(map[string]string)
+		}
+
 		// 如果配置中没有版本信息，尝试自动检测
 		if len(config.Versions) == 0 {
-			detected, detectErr := detect.DetectJDKs()
+			detected, detectErr := detect.DetectJDKs(DetectOptions())
 			if detectErr == nil && len(detected) > 0 {
 				// 添加检测到的JDK
 				for version, path := range detected {
@@ -107,7 +129,7 @@ func Initialize() error {
 				}
 
 				// 设置最新版本为默认版本
-				if latestVer, _, err := detect.GetLatestJDK(); err == nil {
+				if latestVer, _, err := detect.GetLatestJDK(detected); err == nil {
 					config.Settings.Default = latestVer
 				}
 
@@ -116,6 +138,13 @@ func Initialize() error {
 				}
 			}
 		}
+
+		// 补全旧配置中缺少的JDK元数据
+		if fillMetadata() {
+			if err := SaveConfig(); err != nil {
+				return fmt.Errorf("failed to save JDK metadata: %w", err)
+			}
+		}
 	} else {
 		return fmt.Errorf("failed to check config file: %w", err)
 	}
@@ -123,6 +152,23 @@ func Initialize() error {
 	return nil
 }
 
+// Dir 获取javaman的数据目录（~/.javaman）
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, configDirName), nil
+}
+
+// DetectOptions 根据配置生成JDK检测选项
+func DetectOptions() detect.Options {
+	return detect.Options{
+		ScanPaths: config.Settings.ScanPaths,
+		ScanDepth: config.Settings.ScanDepth,
+	}
+}
+
 // GetConfig 获取配置实例
 func GetConfig() *Config {
 	return config
@@ -130,26 +176,56 @@ func GetConfig() *Config {
 
 // SaveConfig 保存配置到文件
 func SaveConfig() error {
-	// 清除现有配置以避免残留
-	viper.Set("versions", nil)
-	viper.Set("settings", nil)
-	viper.Set("aliases", nil)
+	// 清除现有配置以避免残留，已读取的配置文件内容也需要清除，否则删除的条目会被重新写入
+	for _, key := range []string{"versions", "aliases", "jdks"} {
+		if entries, ok := store.Get(key).(map[string]interface{}); ok {
+			for name := range entries {
+				delete(entries, name)
+			}
+		}
+	}
+	store.Set("versions", nil)
+	store.Set("settings", nil)
+	store.Set("aliases", nil)
+	store.Set("jdks", nil)
 
 	// 逐个设置版本路径
 	for version, path := range config.Versions {
-		viper.Set(fmt.Sprintf("versions.%s", version), path)
+		store.Set("versions"+keyDelimiter+version, path)
 	}
 
 	// 设置其他配置
-	viper.Set("settings.default", config.Settings.Default)
-	viper.Set("settings.last_used", config.Settings.LastUsed)
+	store.Set("settings"+keyDelimiter+"default", config.Settings.Default)
+	store.Set("settings"+keyDelimiter+"last_used", config.Settings.LastUsed)
+	if config.Settings.CatalogURL != "" {
+		store.Set("settings"+keyDelimiter+"catalog_url", config.Settings.CatalogURL)
+	}
+	if len(config.Settings.ScanPaths) > 0 {
+		store.Set("settings"+keyDelimiter+"scan_paths", config.Settings.ScanPaths)
+	}
+	if config.Settings.ScanDepth != 0 {
+		store.Set("settings"+keyDelimiter+"scan_depth", config.Settings.ScanDepth)
+	}
 
 	// 逐个设置别名
 	for alias, version := range config.Aliases {
-		viper.Set(fmt.Sprintf("aliases.%s", alias), version)
+		store.Set("aliases"+keyDelimiter+alias, version)
+	}
+
+	// 逐个设置JDK元数据
+	for version, info := range config.JDKs {
+		prefix := "jdks" + keyDelimiter + version + keyDelimiter
+		store.Set(prefix+"java_version", info.JavaVersion)
+		store.Set(prefix+"runtime_version", info.RuntimeVersion)
+		store.Set(prefix+"implementor", info.Implementor)
+		store.Set(prefix+"implementor_version", info.ImplementorVersion)
+		store.Set(prefix+"vendor", info.Vendor)
+		store.Set(prefix+"build_date", info.BuildDate)
+		store.Set(prefix+"arch", info.Arch)
+		store.Set(prefix+"modules", info.Modules)
 	}
 	// 保存到文件
-	return viper.WriteConfig()
+	return store.WriteConfig()
 }
 
 // AddVersion 添加新的JDK版本
@@ -158,18 +234,151 @@ func AddVersion(version, path string) error {
 		config.Versions = make(map[string]string)
 	}
 	config.Versions[version] = path
+
+	// 记录release文件中的元数据
+	if config.JDKs == nil {
+		config.JDKs = make(map[string]detect.JDKInfo)
+	}
+	delete(config.JDKs, version)
+	if info, err := detect.ParseRelease(path); err == nil {
+		config.JDKs[version] = *info
+	}
 	return SaveConfig()
 }
 
 // RemoveVersion 删除JDK版本
 func RemoveVersion(version string) error {
 	delete(config.Versions, version)
-	// 删除viper中的版本信息
-	delete(viper.Get("versions").(map[string]interface{}), version)
+	delete(config.JDKs, version)
 	return SaveConfig()
 }
 
+// RemoveReferences 清除指向版本的默认版本、最后使用版本和别名，需调用SaveConfig保存
+// 指向被删除别名的别名也会一并删除，指向版本范围的别名保留
+// 返回删除的别名以及该版本是否为默认版本
+func RemoveReferences(version string) (aliases []string, wasDefault bool) {
+	if config.Settings.Default == version {
+		config.Settings.Default = ""
+		wasDefault = true
+	}
+	if config.Settings.LastUsed == version {
+		config.Settings.LastUsed = ""
+	}
+	removed := []string{version}
+	for len(removed) > 0 {
+		name := removed[0]
+		removed = removed[1:]
+		for _, alias := range aliasesTo(name) {
+			delete(config.Aliases, alias)
+			aliases = append(aliases, alias)
+			removed = append(removed, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases, wasDefault
+}
+
 // GetVersions 获取所有已配置的JDK版本
 func GetVersions() map[string]string {
 	return config.Versions
 }
+
+// Lookup 根据版本标识或别名查找JDK，返回实际版本标识和路径
+// 除完整标识（temurin-17.0.9）外，还支持版本范围（17、17+、>=11 <21、~17.0）
+// 和带发行商的范围（temurin-17），匹配到多个JDK时选择版本最高的一个
+func Lookup(name string) (version string, path string, err error) {
+	if path, ok := config.Versions[name]; ok {
+		return name, path, nil
+	}
+	if _, ok := config.Aliases[name]; ok {
+		target, _, err := ResolveAlias(name)
+		if err != nil {
+			return "", "", err
+		}
+		if id := bestMatch(target); id != "" {
+			return id, config.Versions[id], nil
+		}
+		return "", "", fmt.Errorf("alias %s points to unknown version %s", name, target)
+	}
+	if id := bestMatch(name); id != "" {
+		return id, config.Versions[id], nil
+	}
+	return "", "", fmt.Errorf("version %s not found. Use 'javaman list' to see available versions", name)
+}
+
+// VersionOf 返回JDK的版本号，没有元数据时返回标识本身
+func VersionOf(id string) string {
+	if info, ok := config.JDKs[id]; ok && info.JavaVersion != "" {
+		return info.JavaVersion
+	}
+	return id
+}
+
+// fullVersionOf 返回JDK带构建号的完整版本号，例如17.0.9+9，用于匹配带构建号的范围
+func fullVersionOf(id string) string {
+	if info, ok := config.JDKs[id]; ok && info.FullVersion() != "" {
+		return info.FullVersion()
+	}
+	return id
+}
+
+// bestMatch 查找满足版本范围的最高版本JDK，未找到时返回空字符串
+// 范围可以带发行商，例如temurin-17、zulu-21+
+func bestMatch(name string) string {
+	if _, ok := config.Versions[name]; ok {
+		return name
+	}
+
+	vendor, rest := detect.SplitQualifiedVersion(name)
+	spec, err := version.ParseSpec(rest)
+	if err != nil {
+		return ""
+	}
+
+	best := ""
+	for id := range config.Versions {
+		if vendor != "" && detect.CanonicalVendor(config.JDKs[id].Vendor) != vendor {
+			continue
+		}
+		if !spec.MatchesString(fullVersionOf(id)) {
+			continue
+		}
+		if best == "" {
+			best = id
+			continue
+		}
+		// 版本相同时按标识排序，保证结果稳定
+		if c := version.CompareStrings(fullVersionOf(id), fullVersionOf(best)); c > 0 || (c == 0 && id < best) {
+			best = id
+		}
+	}
+	return best
+}
+
+// GetInfo 获取JDK版本的元数据
+func GetInfo(version string) (*detect.JDKInfo, bool) {
+	info, ok := config.JDKs[version]
+	if !ok {
+		return nil, false
+	}
+	return &info, true
+}
+
+// fillMetadata 为缺少元数据的JDK读取release文件，返回是否有更新
+func fillMetadata() bool {
+	if config.JDKs == nil {
+		config.JDKs = make(map[string]detect.JDKInfo)
+	}
+
+	changed := false
+	for version, path := range config.Versions {
+		if _, ok := config.JDKs[version]; ok {
+			continue
+		}
+		if info, err := detect.ParseRelease(path); err == nil {
+			config.JDKs[version] = *info
+			changed = true
+		}
+	}
+	return changed
+}
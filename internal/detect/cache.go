@@ -0,0 +1,169 @@
+package detect
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileStamp 文件的修改时间和大小，文件不存在时为零值
+type fileStamp struct {
+	ModTime time.Time `json:"mtime"`
+	Size    int64     `json:"size"`
+}
+
+// fingerprint 判断JDK是否变化的依据：bin/java和release文件
+type fingerprint struct {
+	Java    fileStamp `json:"java"`
+	Release fileStamp `json:"release"`
+}
+
+// cacheEntry 一个JDK的探测结果
+type cacheEntry struct {
+	Fingerprint fingerprint `json:"fingerprint"`
+	Valid       bool        `json:"valid"`
+	Version     string      `json:"version,omitempty"`
+	Vendor      string      `json:"vendor,omitempty"`
+	Arch        string      `json:"arch,omitempty"`
+	HasJavac    bool        `json:"has_javac"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// probeCache 持久化的探测结果，以JDK的实际路径为键
+// bin/java或release文件的修改时间、大小变化时结果自动失效
+type probeCache struct {
+	mu      sync.Mutex
+	file    string
+	loaded  bool
+	dirty   bool
+	entries map[string]cacheEntry
+}
+
+var cache = &probeCache{}
+
+// SetCacheFile 设置探测结果的缓存文件（~/.javaman/cache/jdks.json），为空时不使用缓存
+func SetCacheFile(file string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.file = file
+	cache.loaded = false
+	cache.dirty = false
+	cache.entries = nil
+}
+
+// cacheKey 返回JDK的实际路径，同一安装的不同链接共用一条缓存
+func cacheKey(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path
+}
+
+// fingerprintOf 读取JDK当前的文件指纹
+func fingerprintOf(path string) (fingerprint, error) {
+	java, err := os.Stat(javaBinary(path))
+	if err != nil {
+		return fingerprint{}, err
+	}
+	fp := fingerprint{Java: fileStamp{ModTime: java.ModTime().UTC(), Size: java.Size()}}
+	if release, err := os.Stat(filepath.Join(path, "release")); err == nil {
+		fp.Release = fileStamp{ModTime: release.ModTime().UTC(), Size: release.Size()}
+	}
+	return fp, nil
+}
+
+// lookup 查找指纹未变化的探测结果
+func (c *probeCache) lookup(key string, fp fingerprint) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == "" {
+		return cacheEntry{}, false
+	}
+	c.load()
+	entry, ok := c.entries[key]
+	if !ok || !entry.Fingerprint.Equal(fp) {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// store 记录探测结果，调用save后写入文件
+func (c *probeCache) store(key string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == "" {
+		return
+	}
+	c.load()
+	c.entries[key] = entry
+	c.dirty = true
+}
+
+// load 读取缓存文件，文件不存在或损坏时从空缓存开始，调用方需持有锁
+func (c *probeCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	c.entries = make(map[string]cacheEntry)
+	if content, err := os.ReadFile(c.file); err == nil {
+		if err := json.Unmarshal(content, &c.entries); err != nil {
+			c.entries = make(map[string]cacheEntry)
+		}
+	}
+}
+
+// save 将有变化的缓存写入文件，同时删除已不存在的JDK
+// 缓存只用于加速，写入失败不影响探测结果
+func (c *probeCache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file == "" || !c.dirty {
+		return nil
+	}
+	for key := range c.entries {
+		if _, err := os.Stat(javaBinary(key)); errors.Is(err, os.ErrNotExist) {
+			delete(c.entries, key)
+		}
+	}
+
+	content, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.file), 0755); err != nil {
+		return err
+	}
+	// 先写入临时文件再重命名，避免并发运行的javaman读到不完整的文件
+	tmp, err := os.CreateTemp(filepath.Dir(c.file), ".jdks-*.json")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.file); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// Equal 比较两个指纹，时间按纳秒精度比较，忽略时区
+func (fp fingerprint) Equal(other fingerprint) bool {
+	return fp.Java.ModTime.Equal(other.Java.ModTime) && fp.Java.Size == other.Java.Size &&
+		fp.Release.ModTime.Equal(other.Release.ModTime) && fp.Release.Size == other.Release.Size
+}
@@ -1,7 +1,12 @@
 package detect
 
 import (
+	"context"
+	"fmt"
+	"path/filepath"
 	"strings"
+
+	"javaman/internal/version"
 )
 
 // NormalizeVersion 标准化版本号，只保留主版本号
@@ -35,23 +40,150 @@ func ExtractVersionFromDirName(dirName string) string {
 	return ""
 }
 
-// CompareVersions 比较两个版本号
-func CompareVersions(v1, v2 string) int {
-	parts1 := strings.Split(v1, ".")
-	parts2 := strings.Split(v2, ".")
+// candidate 检测过程中发现的JDK安装
+type candidate struct {
+	path     string
+	fallback string // 无法读取release文件时使用的版本号，为空时使用探测到的版本
+}
+
+// collectJDKs 探测候选JDK并为可用的JDK生成唯一标识，同一安装（包括符号链接）只探测一次
+func collectJDKs(candidates []candidate) map[string]string {
+	var unique []candidate
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		realPath := c.path
+		if resolved, err := filepath.EvalSymlinks(c.path); err == nil {
+			realPath = resolved
+		}
+		if seen[realPath] {
+			continue
+		}
+		seen[realPath] = true
+		unique = append(unique, c)
+	}
+
+	paths := make([]string, len(unique))
+	for i, c := range unique {
+		paths[i] = c.path
+	}
+	probes := ProbeAll(context.Background(), paths)
+
+	// 按候选顺序生成标识，保证结果稳定
+	result := make(map[string]string)
+	for i, c := range unique {
+		if !probes[i].Valid {
+			continue
+		}
+		fallback := c.fallback
+		if fallback == "" && probes[i].Version != "" {
+			fallback = NormalizeVersion(probes[i].Version)
+		}
+
+		info, _ := ParseRelease(c.path)
+		id := JDKID(info, fallback)
+		if id == "" {
+			continue
+		}
+		result[UniqueID(id, func(id string) bool { _, ok := result[id]; return ok })] = c.path
+	}
+	return result
+}
+
+// JDKID 根据JDK元数据生成唯一标识，例如temurin-17.0.9
+// 没有元数据时使用fallback（通常是主版本号）
+// 标识会用作安装目录名和配置键，只保留[a-z0-9._+-]
+func JDKID(info *JDKInfo, fallback string) string {
+	if info == nil || info.JavaVersion == "" {
+		return cleanID(fallback)
+	}
+	vendor := slug(info.Vendor)
+	if vendor == "" {
+		vendor = "jdk"
+	}
+	return vendor + "-" + cleanID(info.JavaVersion)
+}
+
+// ValidID 检查标识是否只包含[a-z0-9._+-]，并且是可以安全用作目录名的单个路径元素
+func ValidID(id string) bool {
+	if id == "" || id == "." || strings.Contains(id, "..") {
+		return false
+	}
+	for _, r := range id {
+		if !isIDChar(r) {
+			return false
+		}
+	}
+	return filepath.IsLocal(id) && filepath.Base(id) == id
+}
+
+// cleanID 将不允许的字符替换为"-"，并去掉连续的"."，避免生成".."等路径
+func cleanID(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if isIDChar(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	id := b.String()
+	for strings.Contains(id, "..") {
+		id = strings.ReplaceAll(id, "..", ".")
+	}
+	return strings.Trim(id, ".-")
+}
 
-	for i := 0; i < len(parts1) && i < len(parts2); i++ {
-		if parts1[i] > parts2[i] {
-			return 1
-		} else if parts1[i] < parts2[i] {
-			return -1
+// isIDChar 检查字符是否可以用于标识
+func isIDChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || strings.ContainsRune("._+-", r)
+}
+
+// UniqueID 在标识已被占用时追加数字后缀，例如temurin-17.0.9-2
+func UniqueID(id string, taken func(string) bool) string {
+	if !taken(id) {
+		return id
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", id, i)
+		if !taken(candidate) {
+			return candidate
 		}
 	}
+}
+
+// slug 将发行商名称转换为可用于标识的小写形式
+func slug(name string) string {
+	var b strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			lastDash = false
+		} else if !lastDash && b.Len() > 0 {
+			b.WriteByte('-')
+			lastDash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
 
-	if len(parts1) > len(parts2) {
-		return 1
-	} else if len(parts1) < len(parts2) {
-		return -1
+// GetLatestJDK 从检测结果中找出版本最高的JDK
+func GetLatestJDK(jdks map[string]string) (id string, path string, err error) {
+	if len(jdks) == 0 {
+		return "", "", fmt.Errorf("no JDK installations found")
 	}
-	return 0
+
+	// 找出最高版本
+	var highestID, highestVersion string
+	for candidateID, p := range jdks {
+		v := candidateID
+		if info, err := ParseRelease(p); err == nil && info.JavaVersion != "" {
+			v = info.JavaVersion
+		}
+		if highestID == "" || version.CompareStrings(v, highestVersion) > 0 {
+			highestID, highestVersion = candidateID, v
+		}
+	}
+
+	return highestID, jdks[highestID], nil
 }
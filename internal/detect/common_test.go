@@ -0,0 +1,56 @@
+package detect
+
+import "testing"
+
+func TestJDKID(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *JDKInfo
+		fallback string
+		want     string
+	}{
+		{"release", &JDKInfo{Vendor: "temurin", JavaVersion: "17.0.9"}, "", "temurin-17.0.9"},
+		{"java 8", &JDKInfo{Vendor: "zulu", JavaVersion: "1.8.0_392"}, "", "zulu-1.8.0_392"},
+		{"no vendor", &JDKInfo{JavaVersion: "21"}, "", "jdk-21"},
+		{"fallback", nil, "17", "17"},
+		{"path traversal", &JDKInfo{JavaVersion: "../../../../pwned"}, "", "jdk-pwned"},
+		{"separators", &JDKInfo{Vendor: "x", JavaVersion: `17/..\evil`}, "", "x-17-.-evil"},
+		{"fallback traversal", nil, "../evil", "evil"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JDKID(tt.info, tt.fallback)
+			if got != tt.want {
+				t.Errorf("JDKID() = %q, want %q", got, tt.want)
+			}
+			if got != "" && !ValidID(got) {
+				t.Errorf("JDKID() = %q is not a valid ID", got)
+			}
+		})
+	}
+}
+
+func TestValidID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"temurin-17.0.9", true},
+		{"temurin-21.0.1+12", true},
+		{"zulu-1.8.0_392", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"jdk-../../pwned", false},
+		{"a/b", false},
+		{`a\b`, false},
+		{"/abs", false},
+		{"Temurin-17", false},
+		{"a b", false},
+	}
+	for _, tt := range tests {
+		if got := ValidID(tt.id); got != tt.want {
+			t.Errorf("ValidID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
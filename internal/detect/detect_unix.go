@@ -4,7 +4,6 @@
 package detect
 
 import (
-	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -25,11 +24,31 @@ var commonJDKPaths = map[string][]string{
 	},
 }
 
+// homebrewPrefixes Homebrew（Linuxbrew）的安装前缀，JDK位于<prefix>/opt/openjdk@17
+var homebrewPrefixes = map[string][]string{
+	"linux": {
+		"/home/linuxbrew/.linuxbrew",
+		"~/.linuxbrew",
+	},
+	"darwin": {
+		"/opt/homebrew",
+		"/usr/local",
+	},
+}
+
+const (
+	// nixStore Nix安装的软件包目录
+	nixStore = "/nix/store"
+	// debianAlternatives Debian/Ubuntu的update-alternatives中java的记录
+	debianAlternatives = "/var/lib/dpkg/alternatives/java"
+	// alternativesLink update-alternatives当前选中的java
+	alternativesLink = "/etc/alternatives/java"
+)
+
 // DetectJDKs 检测系统中已安装的JDK
-func DetectJDKs() (map[string]string, error) {
-	// 使用map来临时存储每个主版本号对应的所有JDK路径
-	tempVersions := make(map[string][]string)
-	result := make(map[string]string)
+func DetectJDKs(opts Options) (map[string]string, error) {
+	// 收集所有候选JDK，同一主版本的多个安装都会保留
+	var candidates []candidate
 
 	// 1. 检查常见安装目录
 	paths := commonJDKPaths[runtime.GOOS]
@@ -45,106 +64,130 @@ func DetectJDKs() (map[string]string, error) {
 						jdkPath = filepath.Join(basePath, entry.Name())
 					}
 
-					if isValidJDKPath(jdkPath) {
+					if hasJava(jdkPath) {
+						// 目录名中没有版本号时仍可通过release文件识别
 						version := ExtractVersionFromDirName(entry.Name())
-						if version != "" {
-							tempVersions[version] = append(tempVersions[version], jdkPath)
-						}
+						candidates = append(candidates, candidate{path: jdkPath, fallback: version})
 					}
 				}
 			}
 		}
 	}
 
-	// 2. 检查环境变量中的Java路径
+	// 2. 检查SDKMAN!、asdf、jabba、IntelliJ IDEA和Gradle安装的JDK
+	for _, base := range toolSources() {
+		candidates = append(candidates, scanChildren(base)...)
+	}
+
+	// 3. 检查包管理器安装的JDK
+	candidates = append(candidates, homebrewJDKs()...)
+	candidates = append(candidates, nixJDKs()...)
+	candidates = append(candidates, alternativesJDKs()...)
+
+	// 4. 检查用户配置的检测目录
+	candidates = append(candidates, scanUserPaths(opts)...)
+
+	// 5. 检查环境变量中的Java路径
 	if javaPath, err := exec.LookPath("java"); err == nil {
 		if realPath, err := filepath.EvalSymlinks(javaPath); err == nil {
 			// 获取JAVA_HOME路径（bin目录的父目录）
 			javaHome := filepath.Dir(filepath.Dir(realPath))
-			if isValidJDKPath(javaHome) {
-				// 尝试获取版本信息
-				if version := getJavaVersion(filepath.Join(javaHome, "bin", "java")); version != "" {
-					tempVersions[version] = append(tempVersions[version], javaHome)
-				}
-			}
-		}
-	}
-
-	// 3. 检查JAVA_HOME环境变量
-	if javaHome := os.Getenv("JAVA_HOME"); javaHome != "" {
-		if isValidJDKPath(javaHome) {
-			if version := getJavaVersion(filepath.Join(javaHome, "bin", "java")); version != "" {
-				tempVersions[version] = append(tempVersions[version], javaHome)
+			if hasJava(javaHome) {
+				// 版本号由探测结果补充
+				candidates = append(candidates, candidate{path: javaHome})
 			}
 		}
 	}
 
-	// 选择每个版本的最佳路径
-	for version, paths := range tempVersions {
-		// 如果有多个路径，选择最后一个（通常是最新安装的）
-		if len(paths) > 0 {
-			result[version] = paths[len(paths)-1]
-		}
+	// 6. 检查JAVA_HOME环境变量
+	if javaHome := os.Getenv("JAVA_HOME"); javaHome != "" && hasJava(javaHome) {
+		candidates = append(candidates, candidate{path: javaHome})
 	}
 
-	return result, nil
+	return collectJDKs(candidates), nil
 }
 
-// isValidJDKPath 验证路径是否包含有效的JDK
-func isValidJDKPath(path string) bool {
-	java := filepath.Join(path, "bin", "java")
-	if _, err := os.Stat(java); err != nil {
-		return false
-	}
-
-	// 验证java是否可以正常运行并返回版本信息
-	cmd := exec.Command(filepath.Join(path, "bin", "java"), "-version")
-	if err := cmd.Run(); err != nil {
-		return false
+// homebrewJDKs 检查Homebrew安装的openjdk和openjdk@<版本>
+func homebrewJDKs() []candidate {
+	var candidates []candidate
+	for _, prefix := range homebrewPrefixes[runtime.GOOS] {
+		matches, _ := filepath.Glob(filepath.Join(expandHome(prefix), "opt", "openjdk*"))
+		for _, dir := range matches {
+			name := filepath.Base(dir)
+			if name != "openjdk" && !strings.HasPrefix(name, "openjdk@") {
+				continue
+			}
+			if home := jdkHome(dir); home != "" && hasJava(home) {
+				_, version, _ := strings.Cut(name, "@")
+				candidates = append(candidates, candidate{path: home, fallback: NormalizeVersion(version)})
+			}
+		}
 	}
-	return true
+	return candidates
 }
 
-// getJavaVersion 通过运行java -version命令获取版本信息
-func getJavaVersion(javaPath string) string {
-	cmd := exec.Command(javaPath, "-version")
-	output, err := cmd.CombinedOutput()
+// nixJDKs 检查/nix/store中的JDK，目录名格式为<hash>-openjdk-17.0.9+9
+func nixJDKs() []candidate {
+	entries, err := os.ReadDir(nixStore)
 	if err != nil {
-		return ""
+		return nil
 	}
 
-	// 解析版本信息
-	versionStr := string(output)
-	lines := strings.Split(versionStr, "\n")
-	if len(lines) > 0 {
-		firstLine := strings.ToLower(lines[0])
-		if strings.Contains(firstLine, "version") {
-			parts := strings.Split(firstLine, `"`)
-			if len(parts) > 1 {
-				return NormalizeVersion(parts[1])
-			}
+	var candidates []candidate
+	for _, entry := range entries {
+		_, name, ok := strings.Cut(entry.Name(), "-")
+		if !ok || !entry.IsDir() || !isNixJDK(name) {
+			continue
+		}
+		if home := jdkHome(filepath.Join(nixStore, entry.Name())); home != "" && hasJava(home) {
+			candidates = append(candidates, candidate{path: home, fallback: dirVersion(name)})
 		}
 	}
-	return ""
+	return candidates
 }
 
-// GetLatestJDK 获取最新的JDK版本和路径
-func GetLatestJDK() (version string, path string, err error) {
-	jdks, err := DetectJDKs()
-	if err != nil {
-		return "", "", err
+// isNixJDK 根据软件包名判断是否可能是JDK，排除文档、调试符号等输出
+func isNixJDK(name string) bool {
+	lower := strings.ToLower(name)
+	for _, suffix := range []string{"-doc", "-debug", "-src", "-man", ".drv"} {
+		if strings.HasSuffix(lower, suffix) {
+			return false
+		}
 	}
-	if len(jdks) == 0 {
-		return "", "", fmt.Errorf("no JDK installations found")
+	for _, keyword := range []string{"jdk", "temurin", "zulu", "corretto", "graalvm"} {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
 	}
+	return false
+}
 
-	// 找出最高版本
-	var highestVersion string
-	for v := range jdks {
-		if highestVersion == "" || CompareVersions(v, highestVersion) > 0 {
-			highestVersion = v
+// alternativesJDKs 检查Debian的update-alternatives中注册的所有java
+func alternativesJDKs() []candidate {
+	javaPaths := []string{alternativesLink}
+	if content, err := os.ReadFile(debianAlternatives); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if filepath.IsAbs(line) && filepath.Base(line) == "java" {
+				javaPaths = append(javaPaths, line)
+			}
 		}
 	}
 
-	return highestVersion, jdks[highestVersion], nil
+	var candidates []candidate
+	for _, javaPath := range javaPaths {
+		realPath, err := filepath.EvalSymlinks(javaPath)
+		if err != nil {
+			continue
+		}
+		home := filepath.Dir(filepath.Dir(realPath))
+		// Java 8的alternatives指向jre/bin/java，JDK根目录在上一级
+		if filepath.Base(home) == "jre" && hasJava(filepath.Dir(home)) {
+			home = filepath.Dir(home)
+		}
+		if hasJava(home) {
+			candidates = append(candidates, candidate{path: home, fallback: dirVersion(filepath.Base(home))})
+		}
+	}
+	return candidates
 }
@@ -4,9 +4,7 @@
 package detect
 
 import (
-	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"golang.org/x/sys/windows/registry"
@@ -19,10 +17,9 @@ var commonJDKPaths = []string{
 }
 
 // DetectJDKs 检测系统中已安装的JDK
-func DetectJDKs() (map[string]string, error) {
-	// 使用map来临时存储每个主版本号对应的所有JDK路径
-	tempVersions := make(map[string][]string)
-	result := make(map[string]string)
+func DetectJDKs(opts Options) (map[string]string, error) {
+	// 收集所有候选JDK，同一主版本的多个安装都会保留
+	var candidates []candidate
 
 	// 1. 检查常见安装目录
 	for _, basePath := range commonJDKPaths {
@@ -30,11 +27,10 @@ func DetectJDKs() (map[string]string, error) {
 			for _, entry := range entries {
 				if entry.IsDir() {
 					jdkPath := filepath.Join(basePath, entry.Name())
-					if isValidJDKPath(jdkPath) {
+					if hasJava(jdkPath) {
+						// 目录名中没有版本号时仍可通过release文件识别
 						version := ExtractVersionFromDirName(entry.Name())
-						if version != "" {
-							tempVersions[version] = append(tempVersions[version], jdkPath)
-						}
+						candidates = append(candidates, candidate{path: jdkPath, fallback: version})
 					}
 				}
 			}
@@ -49,9 +45,9 @@ func DetectJDKs() (map[string]string, error) {
 			for _, regVersion := range subKeys {
 				subKey, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\JavaSoft\Java Development Kit\`+regVersion, registry.READ)
 				if err == nil {
-					if path, _, err := subKey.GetStringValue("JavaHome"); err == nil && isValidJDKPath(path) {
+					if path, _, err := subKey.GetStringValue("JavaHome"); err == nil && hasJava(path) {
 						version := NormalizeVersion(regVersion)
-						tempVersions[version] = append(tempVersions[version], path)
+						candidates = append(candidates, candidate{path: path, fallback: version})
 					}
 					subKey.Close()
 				}
@@ -67,9 +63,9 @@ func DetectJDKs() (map[string]string, error) {
 			for _, regVersion := range subKeys {
 				subKey, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\JavaSoft\JDK\`+regVersion, registry.READ)
 				if err == nil {
-					if path, _, err := subKey.GetStringValue("JavaHome"); err == nil && isValidJDKPath(path) {
+					if path, _, err := subKey.GetStringValue("JavaHome"); err == nil && hasJava(path) {
 						version := NormalizeVersion(regVersion)
-						tempVersions[version] = append(tempVersions[version], path)
+						candidates = append(candidates, candidate{path: path, fallback: version})
 					}
 					subKey.Close()
 				}
@@ -77,49 +73,13 @@ func DetectJDKs() (map[string]string, error) {
 		}
 	}
 
-	// 选择每个版本的最佳路径
-	for version, paths := range tempVersions {
-		// 如果有多个路径，选择最后一个（通常是最新安装的）
-		if len(paths) > 0 {
-			result[version] = paths[len(paths)-1]
-		}
+	// 4. 检查jabba、IntelliJ IDEA和Gradle安装的JDK
+	for _, base := range toolSources() {
+		candidates = append(candidates, scanChildren(base)...)
 	}
 
-	return result, nil
-}
-
-// isValidJDKPath 验证路径是否包含有效的JDK
-func isValidJDKPath(path string) bool {
-	java := filepath.Join(path, "bin", "java.exe")
-	if _, err := os.Stat(java); err != nil {
-		return false
-	}
-
-	// 验证java是否可以正常运行并返回版本信息
-	cmd := exec.Command(filepath.Join(path, "bin", "java.exe"), "-version")
-	if err := cmd.Run(); err != nil {
-		return false
-	}
-	return true
-}
-
-// GetLatestJDK 获取最新的JDK版本和路径
-func GetLatestJDK() (version string, path string, err error) {
-	jdks, err := DetectJDKs()
-	if err != nil {
-		return "", "", err
-	}
-	if len(jdks) == 0 {
-		return "", "", fmt.Errorf("no JDK installations found")
-	}
-
-	// 找出最高版本
-	var highestVersion string
-	for v := range jdks {
-		if highestVersion == "" || CompareVersions(v, highestVersion) > 0 {
-			highestVersion = v
-		}
-	}
+	// 5. 检查用户配置的检测目录
+	candidates = append(candidates, scanUserPaths(opts)...)
 
-	return highestVersion, jdks[highestVersion], nil
+	return collectJDKs(candidates), nil
 }
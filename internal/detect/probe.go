@@ -0,0 +1,183 @@
+package detect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProbeTimeout 单次探测的超时时间，避免无响应的java导致javaman挂起
+const ProbeTimeout = 10 * time.Second
+
+// 同时运行的探测数量，每次探测都会启动一个JVM，按CPU数量限制
+// 但至少保留minProbeWorkers个，避免一个超时的探测拖慢所有探测
+const (
+	minProbeWorkers = 4
+	maxProbeWorkers = 8
+)
+
+// Probe 运行java -version的结果，一次探测同时得到JDK是否可用和版本号
+type Probe struct {
+	Path     string
+	Valid    bool   // bin/java存在并且可以正常运行
+	Version  string // java -version输出中的版本号，例如17.0.9、1.8.0_392
+	Vendor   string // release文件中的发行商
+	Arch     string // release文件中的架构
+	HasJavac bool   // 包含javac，为false时只是JRE
+	Cached   bool   // 结果来自缓存，没有运行java
+	Err      error  // 不可用的原因
+}
+
+// ProbeJDK 探测JDK是否可用并获取版本号，超过ProbeTimeout时终止java进程
+// JDK的文件没有变化时直接使用缓存的结果
+func ProbeJDK(ctx context.Context, path string) Probe {
+	result := probe(ctx, path)
+	cache.save()
+	return result
+}
+
+// probe 优先使用缓存的结果，没有缓存或JDK文件变化时运行java -version
+func probe(ctx context.Context, path string) Probe {
+	fp, err := fingerprintOf(path)
+	if err != nil {
+		return Probe{Path: path, Err: err}
+	}
+
+	key := cacheKey(path)
+	if entry, ok := cache.lookup(key, fp); ok {
+		result := Probe{
+			Path:     path,
+			Valid:    entry.Valid,
+			Version:  entry.Version,
+			Vendor:   entry.Vendor,
+			Arch:     entry.Arch,
+			HasJavac: entry.HasJavac,
+			Cached:   true,
+		}
+		if entry.Error != "" {
+			result.Err = errors.New(entry.Error)
+		}
+		return result
+	}
+
+	result, timedOut := run(ctx, path)
+	// 超时可能只是系统繁忙，不缓存
+	if !timedOut && ctx.Err() == nil {
+		entry := cacheEntry{
+			Fingerprint: fp,
+			Valid:       result.Valid,
+			Version:     result.Version,
+			Vendor:      result.Vendor,
+			Arch:        result.Arch,
+			HasJavac:    result.HasJavac,
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		}
+		cache.store(key, entry)
+	}
+	return result
+}
+
+// run 运行java -version探测JDK，并读取release文件中的发行商和架构
+func run(ctx context.Context, path string) (result Probe, timedOut bool) {
+	result = Probe{Path: path}
+
+	java := javaBinary(path)
+	if info, err := os.Stat(java); err != nil {
+		result.Err = err
+		return result, false
+	} else if info.IsDir() {
+		result.Err = fmt.Errorf("%s is a directory", java)
+		return result, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, java, "-version")
+	// java启动的子进程可能继续占用输出管道，终止后不再等待
+	cmd.WaitDelay = time.Second
+	output, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.Err = fmt.Errorf("%s -version did not finish within %s", java, ProbeTimeout)
+		return result, true
+	}
+	if err != nil {
+		result.Err = fmt.Errorf("%s -version failed: %w", java, err)
+		return result, false
+	}
+
+	result.Valid = true
+	result.Version = parseVersionOutput(string(output))
+	if info, err := ParseRelease(path); err == nil {
+		result.Vendor = info.Vendor
+		result.Arch = info.Arch
+	}
+	if javac, err := os.Stat(toolBinary(path, "javac")); err == nil && !javac.IsDir() {
+		result.HasJavac = true
+	}
+	return result, false
+}
+
+// ProbeAll 使用有限的并发数探测多个JDK，返回结果与paths顺序一致
+func ProbeAll(ctx context.Context, paths []string) []Probe {
+	results := make([]Probe, len(paths))
+	workers := min(max(runtime.NumCPU(), minProbeWorkers), maxProbeWorkers, len(paths))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = probe(ctx, paths[i])
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	cache.save()
+	return results
+}
+
+// parseVersionOutput 从java -version的输出中提取版本号
+// 输出第一行可能是"Picked up JAVA_TOOL_OPTIONS"等提示，因此查找包含version "的行
+func parseVersionOutput(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		idx := strings.Index(line, `version "`)
+		if idx == -1 {
+			continue
+		}
+		rest := line[idx+len(`version "`):]
+		if end := strings.Index(rest, `"`); end != -1 {
+			return rest[:end]
+		}
+	}
+	return ""
+}
+
+// javaBinary 返回JDK中java可执行文件的路径
+func javaBinary(jdkPath string) string {
+	return toolBinary(jdkPath, "java")
+}
+
+// toolBinary 返回JDK中工具可执行文件的路径
+func toolBinary(jdkPath, tool string) string {
+	if runtime.GOOS == "windows" {
+		tool += ".exe"
+	}
+	return filepath.Join(jdkPath, "bin", tool)
+}
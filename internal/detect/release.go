@@ -0,0 +1,83 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadRelease 读取JDK根目录下的release文件，返回其中的键值对
+func ReadRelease(jdkPath string) (map[string]string, error) {
+	content, err := os.ReadFile(filepath.Join(jdkPath, "release"))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return values, nil
+}
+
+// JDKInfo 从release文件中解析出的JDK元数据
+type JDKInfo struct {
+	JavaVersion        string   `mapstructure:"java_version"`        // JAVA_VERSION，例如17.0.9
+	RuntimeVersion     string   `mapstructure:"runtime_version"`     // JAVA_RUNTIME_VERSION，例如17.0.9+9
+	Implementor        string   `mapstructure:"implementor"`         // IMPLEMENTOR，例如Eclipse Adoptium
+	ImplementorVersion string   `mapstructure:"implementor_version"` // IMPLEMENTOR_VERSION，例如Temurin-17.0.9+9
+	Vendor             string   `mapstructure:"vendor"`              // 识别出的发行商，例如temurin
+	BuildDate          string   `mapstructure:"build_date"`          // JAVA_VERSION_DATE
+	Arch               string   `mapstructure:"arch"`                // OS_ARCH
+	Modules            []string `mapstructure:"modules"`             // MODULES
+}
+
+// ParseRelease 解析JDK的release文件
+func ParseRelease(jdkPath string) (*JDKInfo, error) {
+	values, err := ReadRelease(jdkPath)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &JDKInfo{
+		JavaVersion:        values["JAVA_VERSION"],
+		RuntimeVersion:     values["JAVA_RUNTIME_VERSION"],
+		Implementor:        values["IMPLEMENTOR"],
+		ImplementorVersion: values["IMPLEMENTOR_VERSION"],
+		BuildDate:          values["JAVA_VERSION_DATE"],
+		Arch:               values["OS_ARCH"],
+		Modules:            strings.Fields(values["MODULES"]),
+	}
+	info.Vendor = VendorFromImplementor(info.Implementor)
+	if info.Vendor == "" {
+		info.Vendor = info.Implementor
+	}
+	return info, nil
+}
+
+// FullVersion 返回最完整的版本号，优先使用运行时版本
+func (i *JDKInfo) FullVersion() string {
+	if i.RuntimeVersion != "" {
+		return i.RuntimeVersion
+	}
+	return i.JavaVersion
+}
+
+// Summary 返回用于显示的一行摘要，例如"temurin 17.0.9+9, x86_64, built 2023-10-17"
+func (i *JDKInfo) Summary() string {
+	parts := []string{}
+	if head := strings.TrimSpace(i.Vendor + " " + i.FullVersion()); head != "" {
+		parts = append(parts, head)
+	}
+	if i.Arch != "" {
+		parts = append(parts, i.Arch)
+	}
+	if i.BuildDate != "" {
+		parts = append(parts, "built "+i.BuildDate)
+	}
+	return strings.Join(parts, ", ")
+}
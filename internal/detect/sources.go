@@ -0,0 +1,153 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultScanDepth 用户检测目录的默认搜索深度
+const DefaultScanDepth = 2
+
+// Options 检测选项
+type Options struct {
+	// ScanPaths 用户配置的额外检测目录（settings.scan_paths），支持~
+	ScanPaths []string
+	// ScanDepth 在ScanPaths中向下查找的最大层数，0表示使用DefaultScanDepth
+	ScanDepth int
+}
+
+// toolSources 其他工具在用户目录下安装JDK的位置，每个子目录是一个JDK
+// 工具使用环境变量修改了安装位置时优先使用环境变量
+func toolSources() []string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	dirOf := func(envVar string, def ...string) string {
+		if dir := os.Getenv(envVar); dir != "" {
+			return dir
+		}
+		return filepath.Join(append([]string{homeDir}, def...)...)
+	}
+	return []string{
+		// SDKMAN!：~/.sdkman/candidates/java/17.0.9-tem
+		filepath.Join(dirOf("SDKMAN_DIR", ".sdkman"), "candidates", "java"),
+		// asdf：~/.asdf/installs/java/temurin-17.0.9+9
+		filepath.Join(dirOf("ASDF_DATA_DIR", ".asdf"), "installs", "java"),
+		// jabba：~/.jabba/jdk/temurin@17.0.9
+		filepath.Join(dirOf("JABBA_HOME", ".jabba"), "jdk"),
+		// IntelliJ IDEA下载的JDK：~/.jdks/temurin-17.0.9
+		filepath.Join(homeDir, ".jdks"),
+		// Gradle toolchain自动下载的JDK：~/.gradle/jdks/eclipse_adoptium-17-amd64-linux
+		filepath.Join(dirOf("GRADLE_USER_HOME", ".gradle"), "jdks"),
+	}
+}
+
+// scanChildren 将目录的每个子目录作为JDK检查
+func scanChildren(base string) []candidate {
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []candidate
+	for _, entry := range entries {
+		dir := filepath.Join(base, entry.Name())
+		// 子目录可能是符号链接，例如SDKMAN!的current
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		if home := jdkHome(dir); home != "" {
+			candidates = append(candidates, candidate{path: home, fallback: dirVersion(entry.Name())})
+		}
+	}
+	return candidates
+}
+
+// scanTree 在目录中向下查找JDK，最多depth层，找到JDK的目录不再继续查找
+func scanTree(dir string, depth int) []candidate {
+	if home := jdkHome(dir); home != "" {
+		return []candidate{{path: home, fallback: dirVersion(filepath.Base(dir))}}
+	}
+	if depth <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var candidates []candidate
+	for _, entry := range entries {
+		// 不跟随符号链接，避免循环
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		candidates = append(candidates, scanTree(filepath.Join(dir, entry.Name()), depth-1)...)
+	}
+	return candidates
+}
+
+// scanUserPaths 检查用户配置的检测目录
+func scanUserPaths(opts Options) []candidate {
+	depth := opts.ScanDepth
+	if depth <= 0 {
+		depth = DefaultScanDepth
+	}
+
+	var candidates []candidate
+	for _, root := range opts.ScanPaths {
+		candidates = append(candidates, scanTree(expandHome(root), depth)...)
+	}
+	return candidates
+}
+
+// jdkHome 返回目录中实际的JDK根目录，不是JDK时返回空字符串
+// 依次检查macOS的Contents/Home、Homebrew的libexec和Nix的lib/openjdk，
+// 这些布局的外层目录中的bin/java通常只是指向内部的链接
+func jdkHome(dir string) string {
+	for _, home := range []string{
+		filepath.Join(dir, "Contents", "Home"),
+		filepath.Join(dir, "libexec", "openjdk.jdk", "Contents", "Home"),
+		filepath.Join(dir, "libexec"),
+		filepath.Join(dir, "lib", "openjdk"),
+		dir,
+	} {
+		if hasJava(home) {
+			return home
+		}
+	}
+	return ""
+}
+
+// hasJava 检查目录中是否有bin/java，只检查文件是否存在，是否可用由探测确定
+func hasJava(dir string) bool {
+	info, err := os.Stat(javaBinary(dir))
+	return err == nil && !info.IsDir()
+}
+
+// dirVersion 从目录名推断主版本号，例如jdk-17.0.9、17.0.9-tem、temurin@17
+func dirVersion(name string) string {
+	if version := ExtractVersionFromDirName(name); version != "" {
+		return version
+	}
+	_, rest := SplitQualifiedVersion(name)
+	if rest != "" && rest[0] >= '0' && rest[0] <= '9' {
+		return NormalizeVersion(rest)
+	}
+	return ""
+}
+
+// expandHome 展开路径开头的~
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, path[1:])
+}
@@ -0,0 +1,111 @@
+package detect
+
+import "strings"
+
+// vendorAliases 将各工具使用的发行商标识映射为统一名称
+// 包括SDKMAN后缀、asdf-java前缀、jabba前缀等
+var vendorAliases = map[string]string{
+	"tem":               "temurin",
+	"temurin":           "temurin",
+	"adopt":             "adoptopenjdk",
+	"adoptopenjdk":      "adoptopenjdk",
+	"zulu":              "zulu",
+	"azul":              "zulu",
+	"amzn":              "corretto",
+	"corretto":          "corretto",
+	"amazon-corretto":   "corretto",
+	"librca":            "liberica",
+	"liberica":          "liberica",
+	"ms":                "microsoft",
+	"microsoft":         "microsoft",
+	"graalce":           "graalvm-ce",
+	"graalvm-ce":        "graalvm-ce",
+	"graalvm":           "graalvm-ce",
+	"graalvm-community": "graalvm-ce",
+	"graal":             "oracle-graalvm",
+	"oracle-graalvm":    "oracle-graalvm",
+	"oracle":            "oracle",
+	"open":              "openjdk",
+	"openjdk":           "openjdk",
+	"sapmchn":           "sapmachine",
+	"sapmachine":        "sapmachine",
+	"sem":               "semeru",
+	"semeru":            "semeru",
+	"dragonwell":        "dragonwell",
+	"kona":              "kona",
+	"mandrel":           "mandrel",
+	"jbr":               "jetbrains",
+	"jetbrains":         "jetbrains",
+}
+
+// CanonicalVendor 返回发行商的统一名称，无法识别时返回空字符串
+func CanonicalVendor(name string) string {
+	return vendorAliases[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// SplitQualifiedVersion 将带发行商的版本标识拆分为发行商和版本号
+// 支持的格式：temurin-17.0.9（asdf）、17.0.9-tem（SDKMAN）、zulu@1.17（jabba）
+// 无法识别发行商时返回空发行商和原始标识
+func SplitQualifiedVersion(id string) (vendor string, version string) {
+	id = strings.TrimSpace(id)
+
+	// jabba格式：vendor@version
+	if idx := strings.Index(id, "@"); idx != -1 {
+		return CanonicalVendor(id[:idx]), id[idx+1:]
+	}
+
+	// asdf格式：vendor-version，版本号从第一个"-数字"开始
+	for i := 0; i+1 < len(id); i++ {
+		if id[i] == '-' && id[i+1] >= '0' && id[i+1] <= '9' {
+			if vendor := CanonicalVendor(id[:i]); vendor != "" {
+				return vendor, id[i+1:]
+			}
+			break
+		}
+	}
+
+	// SDKMAN格式：version-vendor
+	if idx := strings.LastIndex(id, "-"); idx != -1 {
+		if vendor := CanonicalVendor(id[idx+1:]); vendor != "" {
+			return vendor, id[:idx]
+		}
+	}
+
+	return "", id
+}
+
+// implementorVendors release文件中IMPLEMENTOR关键字与发行商的对应关系
+// GraalVM需在Oracle之前匹配
+var implementorVendors = []struct {
+	keyword string
+	vendor  string
+}{
+	{"adoptium", "temurin"},
+	{"adoptopenjdk", "adoptopenjdk"},
+	{"azul", "zulu"},
+	{"amazon", "corretto"},
+	{"bellsoft", "liberica"},
+	{"microsoft", "microsoft"},
+	{"graalvm community", "graalvm-ce"},
+	{"graalvm", "oracle-graalvm"},
+	{"oracle", "oracle"},
+	{"sap se", "sapmachine"},
+	{"ibm", "semeru"},
+	{"international business machines", "semeru"},
+	{"alibaba", "dragonwell"},
+	{"tencent", "kona"},
+	{"jetbrains", "jetbrains"},
+	{"red hat", "openjdk"},
+}
+
+// VendorFromImplementor 根据release文件中的IMPLEMENTOR识别发行商
+// 无法识别时返回空字符串
+func VendorFromImplementor(implementor string) string {
+	implementor = strings.ToLower(implementor)
+	for _, iv := range implementorVendors {
+		if strings.Contains(implementor, iv.keyword) {
+			return iv.vendor
+		}
+	}
+	return ""
+}
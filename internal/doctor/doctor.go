@@ -0,0 +1,401 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"javaman/internal/backup"
+	"javaman/internal/config"
+	"javaman/internal/detect"
+	"javaman/internal/env"
+	"javaman/internal/shell"
+	"javaman/internal/shim"
+)
+
+// Severity 问题的严重程度
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Finding 一条诊断结果
+type Finding struct {
+	Severity   Severity
+	Message    string
+	Suggestion string       // 修复建议
+	Fix        func() error // 可以安全自动修复时不为空
+}
+
+// Run 执行所有检查
+func Run() []Finding {
+	var findings []Finding
+	findings = append(findings, checkConfig()...)
+	findings = append(findings, checkJavaHome()...)
+	findings = append(findings, checkPath()...)
+	if runtime.GOOS != "windows" {
+		findings = append(findings, checkRcFiles()...)
+		findings = append(findings, checkEnvFiles()...)
+	}
+	return findings
+}
+
+// checkConfig 检查配置中的JDK路径、默认版本和别名
+func checkConfig() []Finding {
+	var findings []Finding
+	cfg := config.GetConfig()
+
+	removed := make(map[string]bool)
+	var existing []string
+	for _, version := range sortedKeys(cfg.Versions) {
+		path := cfg.Versions[version]
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			version := version
+			removed[version] = true
+			findings = append(findings, Finding{
+				Severity:   SeverityError,
+				Message:    fmt.Sprintf("JDK %s points to a missing directory: %s", version, path),
+				Suggestion: fmt.Sprintf("remove it with 'javaman remove %s'", version),
+				Fix: func() error {
+					config.RemoveReferences(version)
+					return config.RemoveVersion(version)
+				},
+			})
+			continue
+		}
+		existing = append(existing, version)
+	}
+
+	// 并发探测其余的JDK
+	paths := make([]string, len(existing))
+	for i, version := range existing {
+		paths[i] = cfg.Versions[version]
+	}
+	for i, probe := range detect.ProbeAll(context.Background(), paths) {
+		if !probe.Valid {
+			findings = append(findings, Finding{
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("JDK %s at %s is not a working JDK: %v", existing[i], probe.Path, probe.Err),
+				Suggestion: fmt.Sprintf("check the installation, or remove it with 'javaman remove %s'", existing[i]),
+			})
+		}
+	}
+
+	// 删除上面的无效JDK时会一并清理引用，这里只检查其他失效的引用
+	settings := []struct {
+		name  string
+		value *string
+	}{
+		{"settings.default", &cfg.Settings.Default},
+		{"settings.last_used", &cfg.Settings.LastUsed},
+	}
+	for _, s := range settings {
+		value := *s.value
+		if value == "" || removed[value] {
+			continue
+		}
+		if _, _, err := config.Lookup(value); err != nil {
+			target := s.value
+			findings = append(findings, Finding{
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("%s refers to unknown version %s", s.name, value),
+				Suggestion: "set it to an installed version, or clear it",
+				Fix: func() error {
+					*target = ""
+					return config.SaveConfig()
+				},
+			})
+		}
+	}
+
+	for _, alias := range sortedKeys(cfg.Aliases) {
+		target := cfg.Aliases[alias]
+		if removed[target] {
+			continue
+		}
+		if _, _, err := config.Lookup(alias); err != nil {
+			alias := alias
+			findings = append(findings, Finding{
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("alias %s is broken: %v", alias, err),
+				Suggestion: "point it to an installed version, or remove it",
+				Fix: func() error {
+					delete(cfg.Aliases, alias)
+					return config.SaveConfig()
+				},
+			})
+		}
+	}
+	return findings
+}
+
+// checkJavaHome 检查当前JAVA_HOME是否指向可用的JDK
+func checkJavaHome() []Finding {
+	javaHome, _ := env.GetJavaHome()
+	if javaHome == "" {
+		if shim.OnPath() {
+			return nil
+		}
+		return []Finding{{
+			Severity:   SeverityInfo,
+			Message:    "JAVA_HOME is not set",
+			Suggestion: "enable shell integration (javaman init) and run 'javaman use <version>'",
+		}}
+	}
+
+	if _, err := os.Stat(javaHome); os.IsNotExist(err) {
+		return []Finding{{
+			Severity:   SeverityError,
+			Message:    fmt.Sprintf("JAVA_HOME points to a missing directory: %s", javaHome),
+			Suggestion: "switch to an installed JDK with 'javaman use <version>', and check the JAVA_HOME exports in your shell startup files",
+		}}
+	}
+	if !env.IsValidJDKPath(javaHome) {
+		return []Finding{{
+			Severity:   SeverityError,
+			Message:    fmt.Sprintf("JAVA_HOME is not a working JDK: %s", javaHome),
+			Suggestion: "switch to an installed JDK with 'javaman use <version>'",
+		}}
+	}
+
+	if managedVersion(javaHome) == "" {
+		return []Finding{{
+			Severity:   SeverityInfo,
+			Message:    fmt.Sprintf("JAVA_HOME is not managed by javaman: %s", javaHome),
+			Suggestion: fmt.Sprintf("add it with 'javaman add %s'", javaHome),
+		}}
+	}
+	return nil
+}
+
+// checkPath 检查PATH中最先找到的java是否来自JAVA_HOME或shim
+func checkPath() []Finding {
+	javaHome, _ := env.GetJavaHome()
+	found, err := exec.LookPath("java")
+	if err != nil {
+		// JAVA_HOME本身无效时已在checkJavaHome中报告
+		if javaHome == "" || !env.IsValidJDKPath(javaHome) {
+			return nil
+		}
+		return []Finding{{
+			Severity:   SeverityWarning,
+			Message:    "java is not on PATH",
+			Suggestion: fmt.Sprintf("add %s to PATH", filepath.Join(javaHome, "bin")),
+		}}
+	}
+
+	// shim会按规则选择JDK
+	if dir, err := shim.Dir(); err == nil && sameFile(filepath.Dir(found), dir) {
+		return nil
+	}
+	if javaHome == "" {
+		return nil
+	}
+	expected := filepath.Join(javaHome, "bin")
+	if sameFile(filepath.Dir(found), expected) || sameFile(found, shim.ToolPath(javaHome, "java")) {
+		return nil
+	}
+	return []Finding{{
+		Severity:   SeverityWarning,
+		Message:    fmt.Sprintf("%s comes before %s on PATH and shadows the JDK in JAVA_HOME", found, expected),
+		Suggestion: fmt.Sprintf("put %s (or ~/.javaman/shims) before %s in PATH, e.g. with 'javaman init'", expected, filepath.Dir(found)),
+	}}
+}
+
+// rcExport shell配置文件中的一条JAVA_HOME导出语句
+type rcExport struct {
+	file  string
+	line  int
+	value string
+}
+
+// checkRcFiles 检查shell配置文件中的JAVA_HOME导出是否一致且有效
+func checkRcFiles() []Finding {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	var exports []rcExport
+	for _, name := range []string{".bashrc", ".bash_profile", ".zshrc", ".zprofile", ".profile"} {
+		exports = append(exports, readExports(filepath.Join(homeDir, name))...)
+	}
+
+	var findings []Finding
+	values := make(map[string]bool)
+	for _, e := range exports {
+		values[e.value] = true
+		path := expandHome(e.value, homeDir)
+		// 使用变量或命令替换的值无法静态判断
+		if strings.ContainsAny(path, "$`") {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			e := e
+			// use --global只维护javaman的管理区块，不会修改这一行，必须删除或注释掉
+			findings = append(findings, Finding{
+				Severity:   SeverityError,
+				Message:    fmt.Sprintf("%s:%d exports JAVA_HOME=%s, which does not exist", e.file, e.line, e.value),
+				Suggestion: fmt.Sprintf("remove or comment out line %d of %s ('javaman use --global' does not change it)", e.line, e.file),
+				Fix: func() error {
+					return commentOutExport(e)
+				},
+			})
+		}
+	}
+
+	if len(values) > 1 {
+		var lines []string
+		for _, e := range exports {
+			lines = append(lines, fmt.Sprintf("%s:%d=%s", e.file, e.line, e.value))
+		}
+		findings = append(findings, Finding{
+			Severity:   SeverityWarning,
+			Message:    "shell startup files export different JAVA_HOME values: " + strings.Join(lines, ", "),
+			Suggestion: "keep a single export, or remove them all and let javaman manage JAVA_HOME with 'javaman use --global <version>'",
+		})
+	}
+	return findings
+}
+
+// checkEnvFiles 检查use --global生成的env文件（~/.javaman/env.sh等）中的JAVA_HOME是否仍然存在
+// shell配置文件只加载这些文件，JDK被删除后新的shell会使用失效的JAVA_HOME
+func checkEnvFiles() []Finding {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	var findings []Finding
+	checked := make(map[string]bool)
+	for _, sh := range shell.Supported() {
+		name, err := shell.EnvFileName(sh)
+		if err != nil || checked[name] {
+			continue
+		}
+		checked[name] = true
+
+		file := filepath.Join(homeDir, ".javaman", name)
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		javaHome, ok := shell.ProfileJavaHome(sh, string(content))
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(javaHome); os.IsNotExist(err) {
+			findings = append(findings, Finding{
+				Severity:   SeverityError,
+				Message:    fmt.Sprintf("%s sets JAVA_HOME=%s, which does not exist", file, javaHome),
+				Suggestion: "regenerate it with 'javaman use --global <version>'",
+			})
+		}
+	}
+	return findings
+}
+
+// readExports 读取文件中的export JAVA_HOME=语句
+func readExports(file string) []rcExport {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	var exports []rcExport
+	for i, line := range strings.Split(string(content), "\n") {
+		if value, ok := parseExport(line); ok {
+			exports = append(exports, rcExport{file: file, line: i + 1, value: value})
+		}
+	}
+	return exports
+}
+
+// parseExport 解析一行export JAVA_HOME=语句，返回去掉引号和行尾注释的值
+func parseExport(line string) (string, bool) {
+	value, ok := strings.CutPrefix(strings.TrimSpace(line), "export JAVA_HOME=")
+	if !ok {
+		return "", false
+	}
+	if idx := strings.Index(value, " #"); idx != -1 {
+		value = value[:idx]
+	}
+	return strings.Trim(strings.TrimSpace(value), `"'`), true
+}
+
+// commentOutExport 注释掉shell配置文件中失效的JAVA_HOME导出语句
+// 修改前备份，可以通过javaman env restore撤销；检查之后该行已被修改时不做任何改动
+func commentOutExport(e rcExport) error {
+	content, err := os.ReadFile(e.file)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(content), "\n")
+	if e.line > len(lines) {
+		return fmt.Errorf("%s:%d has changed since it was checked", e.file, e.line)
+	}
+	line := lines[e.line-1]
+	if value, ok := parseExport(line); !ok || value != e.value {
+		return fmt.Errorf("%s:%d has changed since it was checked", e.file, e.line)
+	}
+	trimmed := strings.TrimLeft(line, " \t")
+	lines[e.line-1] = line[:len(line)-len(trimmed)] + "# disabled by javaman doctor, directory does not exist: " + trimmed
+
+	session := backup.New("doctor --fix " + e.file)
+	defer session.Close()
+	if err := session.WriteFile(e.file, []byte(strings.Join(lines, "\n")), "stale JAVA_HOME export"); err != nil {
+		return fmt.Errorf("failed to write %s: %w", e.file, err)
+	}
+	return session.Close()
+}
+
+// expandHome 展开路径中的~和$HOME
+func expandHome(path, homeDir string) string {
+	switch {
+	case path == "~":
+		return homeDir
+	case strings.HasPrefix(path, "~/"):
+		return filepath.Join(homeDir, path[2:])
+	}
+	path = strings.ReplaceAll(path, "${HOME}", homeDir)
+	return strings.ReplaceAll(path, "$HOME", homeDir)
+}
+
+// managedVersion 返回路径对应的已配置版本，未配置时返回空字符串
+func managedVersion(path string) string {
+	for version, p := range config.GetVersions() {
+		if sameFile(p, path) {
+			return version
+		}
+	}
+	return ""
+}
+
+// sameFile 比较两个路径解析符号链接后是否相同
+func sameFile(a, b string) bool {
+	if realA, err := filepath.EvalSymlinks(a); err == nil {
+		a = realA
+	}
+	if realB, err := filepath.EvalSymlinks(b); err == nil {
+		b = realB
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// sortedKeys 返回排序后的键
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,49 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCommentOutExport(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // Windows
+
+	rc := filepath.Join(home, ".bashrc")
+	content := "alias ll='ls -l'\n  export JAVA_HOME=\"/opt/gone/jdk-11\" # old\nexport PATH=$JAVA_HOME/bin:$PATH\n"
+	if err := os.WriteFile(rc, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	exports := readExports(rc)
+	if len(exports) != 1 || exports[0].line != 2 || exports[0].value != "/opt/gone/jdk-11" {
+		t.Fatalf("readExports() = %+v, want line 2 with /opt/gone/jdk-11", exports)
+	}
+	if err := commentOutExport(exports[0]); err != nil {
+		t.Fatalf("commentOutExport() error: %v", err)
+	}
+
+	got, err := os.ReadFile(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "alias ll='ls -l'\n  # disabled by javaman doctor, directory does not exist: export JAVA_HOME=\"/opt/gone/jdk-11\" # old\nexport PATH=$JAVA_HOME/bin:$PATH\n"
+	if string(got) != want {
+		t.Errorf(".bashrc =\n%s\nwant\n%s", got, want)
+	}
+	// 原子写入时保留原文件的权限
+	if info, err := os.Stat(rc); err == nil && runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf(".bashrc mode = %v, want 0600", info.Mode().Perm())
+	}
+	if exports := readExports(rc); len(exports) != 0 {
+		t.Errorf("readExports() after fix = %+v, want none", exports)
+	}
+
+	// 检查之后该行已被修改时不做改动
+	if err := commentOutExport(rcExport{file: rc, line: 2, value: "/opt/gone/jdk-11"}); err == nil {
+		t.Error("commentOutExport() succeeded on a line that has changed, want error")
+	}
+}
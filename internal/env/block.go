@@ -0,0 +1,66 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 配置文件中javaman管理区块的起止标记
+const (
+	blockStart = "# >>> javaman >>>"
+	blockEnd   = "# <<< javaman <<<"
+)
+
+// managedBlock 生成带起止标记的管理区块
+func managedBlock(body string) string {
+	return blockStart + "\n" +
+		"# Managed by javaman, changes inside this block will be overwritten\n" +
+		strings.TrimSuffix(body, "\n") + "\n" +
+		blockEnd + "\n"
+}
+
+// replaceBlock 用新的区块替换content中已有的管理区块，没有时追加到末尾
+// 区块之外的内容（包括文件末尾是否有换行）保持不变
+func replaceBlock(content, block string) (string, error) {
+	start, _ := findMarker(content, blockStart, 0)
+	if start == -1 {
+		if content == "" {
+			return block, nil
+		}
+		// 追加时与原有内容之间空一行
+		switch {
+		case strings.HasSuffix(content, "\n\n"):
+		case strings.HasSuffix(content, "\n"):
+			content += "\n"
+		default:
+			content += "\n\n"
+		}
+		return content + block, nil
+	}
+
+	endLine, after := findMarker(content, blockEnd, start)
+	if endLine == -1 {
+		return "", fmt.Errorf("found %q without matching %q", blockStart, blockEnd)
+	}
+	// 原区块位于文件末尾且没有换行时保持原样
+	if !strings.HasSuffix(content[:after], "\n") {
+		block = strings.TrimSuffix(block, "\n")
+	}
+	return content[:start] + block + content[after:], nil
+}
+
+// findMarker 从from开始查找单独成行的标记，返回该行的起始位置和下一行的起始位置
+// 未找到时返回-1
+func findMarker(content, marker string, from int) (int, int) {
+	for offset := from; offset < len(content); {
+		next := len(content)
+		if idx := strings.IndexByte(content[offset:], '\n'); idx != -1 {
+			next = offset + idx + 1
+		}
+		if strings.TrimSpace(content[offset:next]) == marker {
+			return offset, next
+		}
+		offset = next
+	}
+	return -1, -1
+}
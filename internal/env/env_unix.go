@@ -6,106 +6,137 @@ package env
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strings"
+	"runtime"
+
+	"javaman/internal/backup"
+	"javaman/internal/shell"
 )
 
 // SetJavaHome 设置JAVA_HOME环境变量
+// 每种shell的设置写入~/.javaman中生成的env文件，shell配置文件中只维护一个加载该文件的
+// 管理区块（# >>> javaman >>> 到 # <<< javaman <<<），区块之外的内容不会被修改
 func SetJavaHome(jdkPath string) error {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
-
-	// 根据不同的shell修改配置文件
-	shellRcFiles := []string{
-		filepath.Join(homeDir, ".bashrc"),
-		filepath.Join(homeDir, ".zshrc"),
-		filepath.Join(homeDir, ".profile"),
-	}
-
-	// 读取现有PATH
-	currentPath := os.Getenv("PATH")
-	paths := strings.Split(currentPath, ":")
-	newPaths := []string{}
-
-	// 移除旧的Java相关路径
-	for _, p := range paths {
-		if !strings.Contains(strings.ToLower(p), "java") && !strings.Contains(strings.ToLower(p), "jdk") {
-			newPaths = append(newPaths, p)
+	dataDir := filepath.Join(homeDir, ".javaman")
+
+	// 修改前备份所有文件，可以通过javaman env restore撤销
+	session := backup.New("use --global " + jdkPath)
+	defer session.Close()
+
+	written := make(map[string]bool)
+	for _, target := range profileTargets(homeDir) {
+		// 生成env文件
+		name, err := shell.EnvFileName(target.shell)
+		if err != nil {
+			return err
 		}
-	}
-
-	// 添加新的Java路径
-	binPath := filepath.Join(jdkPath, "bin")
-	newPaths = append(newPaths, binPath)
-	newPath := strings.Join(newPaths, ":")
-
-	// 更新shell配置文件
-	for _, rcFile := range shellRcFiles {
-		if _, err := os.Stat(rcFile); err == nil {
-			content, err := os.ReadFile(rcFile)
+		envFile := filepath.Join(dataDir, name)
+		if !written[envFile] {
+			script, err := shell.Profile(target.shell, jdkPath)
 			if err != nil {
-				continue
-			}
-
-			lines := strings.Split(string(content), "\n")
-			newLines := []string{}
-			javaHomeFound := false
-			pathFound := false
-
-			for _, line := range lines {
-				if strings.HasPrefix(line, "export JAVA_HOME=") {
-					newLines = append(newLines, fmt.Sprintf("export JAVA_HOME=%s", jdkPath))
-					javaHomeFound = true
-				} else if strings.HasPrefix(line, "export PATH=") {
-					newLines = append(newLines, fmt.Sprintf("export PATH=%s", newPath))
-					pathFound = true
-				} else {
-					newLines = append(newLines, line)
-				}
+				return err
 			}
-
-			if !javaHomeFound {
-				newLines = append(newLines, fmt.Sprintf("export JAVA_HOME=%s", jdkPath))
-			}
-			if !pathFound {
-				newLines = append(newLines, fmt.Sprintf("export PATH=%s", newPath))
+			if err := session.WriteFile(envFile, []byte("# Generated by javaman, do not edit\n"+script), "generated env file"); err != nil {
+				return fmt.Errorf("failed to write %s: %w", envFile, err)
 			}
+			written[envFile] = true
+		}
 
-			err = os.WriteFile(rcFile, []byte(strings.Join(newLines, "\n")), 0644)
-			if err != nil {
-				return fmt.Errorf("failed to update %s: %w", rcFile, err)
-			}
+		// 在配置文件中加载env文件
+		source, err := shell.SourceLine(target.shell, envFile)
+		if err != nil {
+			return err
+		}
+		if err := updateBlock(session, target.path, managedBlock(source)); err != nil {
+			return fmt.Errorf("failed to update %s: %w", target.path, err)
 		}
 	}
 
 	// 立即更新当前会话的环境变量
+	os.Setenv("PATH", SessionPath(jdkPath))
 	os.Setenv("JAVA_HOME", jdkPath)
-	os.Setenv("PATH", newPath)
 
-	return nil
+	return session.Close()
 }
 
-// GetJavaHome 获取当前JAVA_HOME环境变量
-func GetJavaHome() (string, error) {
-	return os.Getenv("JAVA_HOME"), nil
+// profile 需要写入的shell配置文件
+type profile struct {
+	path  string
+	shell string
 }
 
-// IsValidJDKPath 验证JDK路径是否有效
-func IsValidJDKPath(path string) bool {
-	// 检查java是否存在
-	javaExe := filepath.Join(path, "bin", "java")
-	if _, err := os.Stat(javaExe); err != nil {
-		return false
+// profileTargets 根据$SHELL和已存在的配置目录确定需要写入的配置文件
+// 已存在的配置文件都会更新；当前shell的配置文件不存在时会被创建
+func profileTargets(homeDir string) []profile {
+	current := filepath.Base(os.Getenv("SHELL"))
+	var targets []profile
+
+	for _, rc := range []struct {
+		name  string
+		shell string
+	}{
+		{".bashrc", shell.Bash},
+		{".zshrc", shell.Zsh},
+		{".profile", shell.Bash},
+	} {
+		path := filepath.Join(homeDir, rc.name)
+		if fileExists(path) || (rc.shell == current && rc.name != ".profile") {
+			targets = append(targets, profile{path: path, shell: rc.shell})
+		}
 	}
 
-	// 测试java是否可以正常运行
-	cmd := exec.Command(javaExe, "-version")
-	if err := cmd.Run(); err != nil {
-		return false
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(homeDir, ".config")
 	}
 
-	return true
+	// fish会自动加载conf.d中的文件，使用独立的文件而不修改config.fish
+	fishDir := filepath.Join(configHome, "fish")
+	if current == shell.Fish || fileExists(fishDir) {
+		targets = append(targets, profile{path: filepath.Join(fishDir, "conf.d", "javaman.fish"), shell: shell.Fish})
+	}
+
+	// nushell在macOS上默认使用~/Library/Application Support/nushell
+	nuDir := filepath.Join(configHome, "nushell")
+	if runtime.GOOS == "darwin" && os.Getenv("XDG_CONFIG_HOME") == "" {
+		nuDir = filepath.Join(homeDir, "Library", "Application Support", "nushell")
+	}
+	if current == shell.Nushell || fileExists(nuDir) {
+		targets = append(targets, profile{path: filepath.Join(nuDir, "env.nu"), shell: shell.Nushell})
+	}
+
+	pwshDir := filepath.Join(configHome, "powershell")
+	if current == shell.PowerShell || fileExists(pwshDir) {
+		targets = append(targets, profile{path: filepath.Join(pwshDir, "Microsoft.PowerShell_profile.ps1"), shell: shell.PowerShell})
+	}
+
+	return targets
+}
+
+// updateBlock 写入配置文件中的管理区块，内容没有变化时不写入
+func updateBlock(session *backup.Session, file, block string) error {
+	content, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	updated, err := replaceBlock(string(content), block)
+	if err != nil {
+		return err
+	}
+	return session.WriteFile(file, []byte(updated), "javaman block")
+}
+
+// fileExists 检查文件或目录是否存在
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// GetJavaHome 获取当前JAVA_HOME环境变量
+func GetJavaHome() (string, error) {
+	return os.Getenv("JAVA_HOME"), nil
 }
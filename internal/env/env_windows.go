@@ -5,8 +5,6 @@ package env
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"syscall"
@@ -81,23 +79,6 @@ func GetJavaHome() (string, error) {
 	return javaHome, nil
 }
 
-// IsValidJDKPath 验证JDK路径是否有效
-func IsValidJDKPath(path string) bool {
-	// 检查java.exe是否存在
-	javaExe := filepath.Join(path, "bin", "java.exe")
-	if _, err := os.Stat(javaExe); err != nil {
-		return false
-	}
-
-	// 测试java.exe是否可以正常运行
-	cmd := exec.Command(javaExe, "-version")
-	if err := cmd.Run(); err != nil {
-		return false
-	}
-
-	return true
-}
-
 // 广播环境变量更改消息
 func broadcastEnvChange() {
 	// 加载user32.dll
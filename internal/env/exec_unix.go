@@ -0,0 +1,18 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package env
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Exec 用指定程序替换当前进程，退出码和信号由新进程直接处理
+func Exec(path string, args []string, environ []string) error {
+	argv := append([]string{path}, args...)
+	if err := syscall.Exec(path, argv, environ); err != nil {
+		return fmt.Errorf("failed to execute %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+//go:build windows
+// +build windows
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+)
+
+// Exec 运行指定程序并以其退出码退出当前进程
+// Windows不支持替换进程，Ctrl+C会同时发送给子进程，这里只需忽略它等待子进程结束
+func Exec(path string, args []string, environ []string) error {
+	cmd := exec.Command(path, args...)
+	cmd.Env = environ
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to execute %s: %w", path, err)
+	}
+	os.Exit(0)
+	return nil
+}
@@ -0,0 +1,12 @@
+package env
+
+import (
+	"context"
+
+	"javaman/internal/detect"
+)
+
+// IsValidJDKPath 验证JDK路径是否有效：bin/java存在并能在超时时间内正常运行
+func IsValidJDKPath(path string) bool {
+	return detect.ProbeJDK(context.Background(), path).Valid
+}
@@ -0,0 +1,76 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SessionPath 计算切换到指定JDK后当前会话应使用的PATH
+// 会移除旧JAVA_HOME的bin目录，并将新JDK的bin目录放在最前面
+func SessionPath(jdkPath string) string {
+	binPath := filepath.Join(jdkPath, "bin")
+	oldBin := ""
+	if oldHome := os.Getenv("JAVA_HOME"); oldHome != "" {
+		oldBin = filepath.Join(oldHome, "bin")
+	}
+
+	newPaths := []string{binPath}
+	for _, p := range filepath.SplitList(os.Getenv("PATH")) {
+		if p == "" {
+			continue
+		}
+		clean := filepath.Clean(p)
+		if clean == binPath || (oldBin != "" && clean == oldBin) {
+			continue
+		}
+		newPaths = append(newPaths, p)
+	}
+
+	return strings.Join(newPaths, string(os.PathListSeparator))
+}
+
+// Environ 返回切换到指定JDK后子进程使用的环境变量列表
+func Environ(jdkPath string) []string {
+	overrides := map[string]string{
+		"JAVA_HOME": jdkPath,
+		"PATH":      SessionPath(jdkPath),
+	}
+
+	result := make([]string, 0, len(os.Environ())+len(overrides))
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if hasName(overrides, name) {
+			continue
+		}
+		result = append(result, kv)
+	}
+	for name, value := range overrides {
+		result = append(result, name+"="+value)
+	}
+	return result
+}
+
+// SetVar 在环境变量列表中设置变量，已有的同名变量会被替换
+func SetVar(environ []string, name, value string) []string {
+	vars := map[string]string{name: value}
+	result := make([]string, 0, len(environ)+1)
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		if hasName(vars, key) {
+			continue
+		}
+		result = append(result, kv)
+	}
+	return append(result, name+"="+value)
+}
+
+// hasName 检查环境变量名是否存在，Windows下不区分大小写
+func hasName(vars map[string]string, name string) bool {
+	for key := range vars {
+		if key == name || (IsWindows() && strings.EqualFold(key, name)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,286 @@
+package install
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// archiveType 根据声明的格式或文件名判断压缩格式
+func archiveType(declared, name string) (string, error) {
+	switch strings.ToLower(declared) {
+	case "tar.gz", "tgz":
+		return "tar.gz", nil
+	case "zip":
+		return "zip", nil
+	}
+
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz", nil
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip", nil
+	}
+	return "", fmt.Errorf("unsupported archive format: %s", name)
+}
+
+// extract 将压缩包解压到dest目录
+// 所有条目（包括符号链接指向的位置）都必须位于dest内
+func extract(archive, kind, dest string) error {
+	realDest, err := filepath.EvalSymlinks(dest)
+	if err != nil {
+		return err
+	}
+
+	var links []string
+	switch kind {
+	case "tar.gz":
+		links, err = extractTarGz(archive, realDest)
+	case "zip":
+		links, err = extractZip(archive, realDest)
+	default:
+		return fmt.Errorf("unsupported archive format: %s", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 所有文件解压完成后，再次确认符号链接的真实位置没有跳出目标目录
+	for _, link := range links {
+		resolved, err := filepath.EvalSymlinks(link)
+		if err != nil {
+			continue
+		}
+		if !within(realDest, resolved) {
+			return fmt.Errorf("archive symlink %s resolves outside the target directory", link)
+		}
+	}
+	return nil
+}
+
+// extractTarGz 解压tar.gz文件，返回创建的符号链接
+func extractTarGz(archive, dest string) ([]string, error) {
+	file, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", archive, err)
+	}
+	defer gz.Close()
+
+	var links []string
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return links, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", archive, err)
+		}
+
+		if isRootEntry(header.Name) {
+			continue
+		}
+		target, err := entryPath(dest, header.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := prepareTarget(dest, target); err != nil {
+			return nil, err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, header.FileInfo().Mode()); err != nil {
+				return nil, err
+			}
+		case tar.TypeSymlink:
+			if err := createSymlink(dest, target, header.Linkname); err != nil {
+				return nil, err
+			}
+			links = append(links, target)
+		case tar.TypeLink:
+			// 硬链接的源文件必须是已解压的普通文件
+			source, err := entryPath(dest, header.Linkname)
+			if err != nil {
+				return nil, err
+			}
+			if info, err := os.Lstat(source); err != nil || !info.Mode().IsRegular() {
+				return nil, fmt.Errorf("archive hard link %s points to invalid entry %s", header.Name, header.Linkname)
+			}
+			if err := os.Link(source, target); err != nil {
+				return nil, fmt.Errorf("failed to create link %s: %w", header.Name, err)
+			}
+		}
+		// 其他类型（设备文件等）忽略
+	}
+}
+
+// extractZip 解压zip文件，返回创建的符号链接
+func extractZip(archive, dest string) ([]string, error) {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", archive, err)
+	}
+	defer reader.Close()
+
+	var links []string
+	for _, f := range reader.File {
+		if isRootEntry(f.Name) {
+			continue
+		}
+		target, err := entryPath(dest, f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := prepareTarget(dest, target); err != nil {
+			return nil, err
+		}
+
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return nil, err
+			}
+		case mode&os.ModeSymlink != 0:
+			linkname, err := readZipEntry(f)
+			if err != nil {
+				return nil, err
+			}
+			if err := createSymlink(dest, target, linkname); err != nil {
+				return nil, err
+			}
+			links = append(links, target)
+		default:
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+			}
+			err = writeFile(target, rc, mode)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return links, nil
+}
+
+// isRootEntry 检查条目是否为压缩包根目录本身，例如tar -C dir .生成的"./"
+func isRootEntry(name string) bool {
+	name = strings.TrimPrefix(name, "./")
+	return name == "" || name == "." || name == "/"
+}
+
+// entryPath 计算压缩包条目的解压路径，拒绝绝对路径和跳出目标目录的路径（zip slip）
+func entryPath(dest, name string) (string, error) {
+	name = filepath.FromSlash(strings.TrimPrefix(name, "./"))
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("archive entry %q escapes the target directory", name)
+	}
+	return filepath.Join(dest, name), nil
+}
+
+// prepareTarget 确认条目的父目录真实位置位于目标目录内，防止经由已解压的符号链接写到目录外，
+// 然后创建父目录并删除已存在的同名文件或链接
+func prepareTarget(dest, target string) error {
+	// 找到最近的已存在的上级目录并解析其真实路径
+	existing := filepath.Dir(target)
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		existing = filepath.Dir(existing)
+	}
+	realParent, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return err
+	}
+	if !within(dest, realParent) {
+		return fmt.Errorf("archive entry %s escapes the target directory through a symlink", target)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if info, err := os.Lstat(target); err == nil && !info.IsDir() {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createSymlink 创建符号链接，链接目标必须是相对路径且位于目标目录内
+func createSymlink(dest, target, linkname string) error {
+	if filepath.IsAbs(linkname) || strings.HasPrefix(linkname, "/") {
+		return fmt.Errorf("archive symlink %s points to absolute path %s", target, linkname)
+	}
+	realParent, err := filepath.EvalSymlinks(filepath.Dir(target))
+	if err != nil {
+		return err
+	}
+	if !within(dest, filepath.Join(realParent, filepath.FromSlash(linkname))) {
+		return fmt.Errorf("archive symlink %s points outside the target directory: %s", target, linkname)
+	}
+
+	if err := os.Symlink(linkname, target); err != nil {
+		return fmt.Errorf("failed to create symlink %s: %w", target, err)
+	}
+	return nil
+}
+
+// within 检查path是否位于root内（包括root本身）
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && (rel == "." || filepath.IsLocal(rel))
+}
+
+// writeFile 写入普通文件，只保留权限位
+func writeFile(target string, r io.Reader, mode os.FileMode) error {
+	perm := mode.Perm()
+	if perm == 0 {
+		perm = 0644
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", target, err)
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	return file.Close()
+}
+
+// readZipEntry 读取zip条目的全部内容
+func readZipEntry(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	return string(content), nil
+}
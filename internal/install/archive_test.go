@@ -0,0 +1,205 @@
+package install
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// entry 测试压缩包中的一个条目
+type entry struct {
+	name string
+	kind byte   // tar类型，zip只使用TypeReg、TypeDir和TypeSymlink
+	body string // 文件内容，链接时为链接目标
+}
+
+func writeTarGz(t *testing.T, file string, entries []entry) {
+	t.Helper()
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Typeflag: e.kind, Mode: 0644}
+		switch e.kind {
+		case tar.TypeReg:
+			header.Size = int64(len(e.body))
+		case tar.TypeDir:
+			header.Mode = 0755
+		case tar.TypeSymlink, tar.TypeLink:
+			header.Linkname = e.body
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if e.kind == tar.TypeReg {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, file string, entries []entry) {
+	t.Helper()
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		switch e.kind {
+		case tar.TypeDir:
+			header.SetMode(os.ModeDir | 0755)
+		case tar.TypeSymlink:
+			header.SetMode(os.ModeSymlink | 0777)
+		default:
+			header.SetMode(0644)
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// hasSymlink 检查条目中是否有符号链接，Windows创建符号链接需要额外权限
+func hasSymlink(entries []entry) bool {
+	for _, e := range entries {
+		if e.kind == tar.TypeSymlink {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExtractValid(t *testing.T) {
+	entries := []entry{
+		{"jdk/", tar.TypeDir, ""},
+		{"jdk/bin/java", tar.TypeReg, "java"},
+		{"jdk/release", tar.TypeReg, `JAVA_VERSION="17.0.9"`},
+		{"jdk/lib/", tar.TypeDir, ""},
+		{"jdk/lib/current", tar.TypeSymlink, "../bin"},
+	}
+	for _, kind := range []string{"tar.gz", "zip"} {
+		t.Run(kind, func(t *testing.T) {
+			if runtime.GOOS == "windows" {
+				t.Skip("symlinks require extra privileges on Windows")
+			}
+			dir := t.TempDir()
+			archive := filepath.Join(dir, "archive."+kind)
+			if kind == "zip" {
+				writeZip(t, archive, entries)
+			} else {
+				writeTarGz(t, archive, append(entries, entry{"jdk/bin/java-link", tar.TypeLink, "jdk/bin/java"}))
+			}
+			dest := filepath.Join(dir, "dest")
+			if err := os.Mkdir(dest, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := extract(archive, kind, dest); err != nil {
+				t.Fatalf("extract() error: %v", err)
+			}
+			content, err := os.ReadFile(filepath.Join(dest, "jdk", "lib", "current", "java"))
+			if err != nil || string(content) != "java" {
+				t.Errorf("symlinked java = %q, %v", content, err)
+			}
+			if kind == "tar.gz" {
+				if content, err := os.ReadFile(filepath.Join(dest, "jdk", "bin", "java-link")); err != nil || string(content) != "java" {
+					t.Errorf("hard linked java = %q, %v", content, err)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractRejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []entry
+		zip     bool // 同样的条目也用zip测试（zip没有硬链接）
+	}{
+		{"parent directory", []entry{{"../evil", tar.TypeReg, "x"}}, true},
+		{"nested parent directory", []entry{{"jdk/../../evil", tar.TypeReg, "x"}}, true},
+		{"absolute path", []entry{{"/tmp/evil", tar.TypeReg, "x"}}, true},
+		{"absolute symlink", []entry{{"jdk/link", tar.TypeSymlink, "/etc"}}, true},
+		{"escaping symlink", []entry{{"jdk/link", tar.TypeSymlink, "../../evil"}}, true},
+		{"write through escaping symlink", []entry{
+			// d/..在文本上位于目标目录内，但d是指向目标目录的链接，实际解析为目标目录的上级
+			{"d", tar.TypeSymlink, "."},
+			{"e", tar.TypeSymlink, "d/.."},
+			{"e/evil", tar.TypeReg, "x"},
+		}, true},
+		{"symlink then file below it", []entry{
+			{"jdk/", tar.TypeDir, ""},
+			{"jdk/lib", tar.TypeSymlink, "../../"},
+			{"jdk/lib/evil", tar.TypeReg, "x"},
+		}, true},
+		{"hard link outside", []entry{{"jdk/passwd", tar.TypeLink, "../outside"}}, false},
+		{"hard link to absolute path", []entry{{"jdk/passwd", tar.TypeLink, "/etc/passwd"}}, false},
+		{"hard link to symlink", []entry{
+			{"jdk/", tar.TypeDir, ""},
+			{"jdk/link", tar.TypeSymlink, "."},
+			{"jdk/hard", tar.TypeLink, "jdk/link"},
+		}, false},
+	}
+	for _, tt := range tests {
+		kinds := []string{"tar.gz"}
+		if tt.zip {
+			kinds = append(kinds, "zip")
+		}
+		for _, kind := range kinds {
+			t.Run(tt.name+"/"+kind, func(t *testing.T) {
+				if runtime.GOOS == "windows" && hasSymlink(tt.entries) {
+					t.Skip("symlinks require extra privileges on Windows")
+				}
+				dir := t.TempDir()
+				archive := filepath.Join(dir, "archive."+kind)
+				if kind == "zip" {
+					writeZip(t, archive, tt.entries)
+				} else {
+					writeTarGz(t, archive, tt.entries)
+				}
+				// 目标目录放在下一级，使逃逸的文件仍落在临时目录中便于检查
+				dest := filepath.Join(dir, "a", "dest")
+				if err := os.MkdirAll(dest, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "a", "outside"), []byte("secret"), 0644); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := extract(archive, kind, dest); err == nil {
+					t.Fatal("extract() succeeded, want error")
+				}
+				for _, name := range []string{filepath.Join(dir, "evil"), filepath.Join(dir, "a", "evil")} {
+					if _, err := os.Lstat(name); err == nil {
+						t.Errorf("%s was created outside the target directory", name)
+					}
+				}
+			})
+		}
+	}
+}
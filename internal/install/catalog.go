@@ -0,0 +1,159 @@
+package install
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"runtime"
+	"strings"
+
+	"javaman/internal/detect"
+	"javaman/internal/version"
+)
+
+// Release 可下载的JDK发布包
+type Release struct {
+	Vendor      string `json:"vendor"`       // 发行商，例如temurin
+	Version     string `json:"version"`      // 版本号，例如21.0.1+12
+	OS          string `json:"os"`           // 操作系统：linux、macos、windows
+	Arch        string `json:"arch"`         // 架构：x64、aarch64、x86
+	ArchiveType string `json:"archive_type"` // 压缩格式：tar.gz、zip
+	URL         string `json:"url"`          // 下载地址，相对地址基于目录地址解析
+	SHA256      string `json:"sha256"`       // 压缩包的SHA-256校验和
+	ChecksumURL string `json:"sha256_url"`   // SHA-256校验和文件地址，未提供sha256时使用
+	SHA1        string `json:"sha1"`         // 发行商只提供SHA-1时使用
+}
+
+// ID 返回安装后预期的标识，例如temurin-21.0.1
+func (r *Release) ID() string {
+	v, _, _ := strings.Cut(r.Version, "+")
+	return r.Vendor + "-" + v
+}
+
+// catalog 发布包目录文件格式
+type catalog struct {
+	JDKs []Release `json:"jdks"`
+}
+
+// FetchCatalog 下载并解析发布包目录，支持http(s)和file地址
+func FetchCatalog(catalogURL string) ([]Release, error) {
+	body, err := open(catalogURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch catalog: %w", err)
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+	return ParseCatalog(catalogURL, content)
+}
+
+// ParseCatalog 解析发布包目录，并将相对下载地址解析为绝对地址
+func ParseCatalog(catalogURL string, content []byte) ([]Release, error) {
+	var c catalog
+	if err := json.Unmarshal(content, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
+	}
+
+	base, err := url.Parse(catalogURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalog URL %s: %w", catalogURL, err)
+	}
+	for i := range c.JDKs {
+		ref, err := url.Parse(c.JDKs[i].URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid download URL %s: %w", c.JDKs[i].URL, err)
+		}
+		c.JDKs[i].URL = base.ResolveReference(ref).String()
+		if c.JDKs[i].ChecksumURL != "" {
+			if ref, err := url.Parse(c.JDKs[i].ChecksumURL); err == nil {
+				c.JDKs[i].ChecksumURL = base.ResolveReference(ref).String()
+			}
+		}
+		c.JDKs[i].Vendor = normalizeVendor(c.JDKs[i].Vendor)
+	}
+	return c.JDKs, nil
+}
+
+// ParseRequest 解析vendor@spec格式的请求，例如temurin@21、zulu@17+
+// 也支持temurin-21的写法；省略发行商时返回空发行商
+func ParseRequest(request string) (vendor string, spec *version.Spec, err error) {
+	vendor, rest := detect.SplitQualifiedVersion(request)
+	if vendor == "" && strings.Contains(request, "@") {
+		// 未知发行商名称原样使用
+		name, specPart, _ := strings.Cut(request, "@")
+		vendor, rest = strings.ToLower(name), specPart
+	}
+	spec, err = version.ParseSpec(rest)
+	if err != nil {
+		return "", nil, err
+	}
+	return vendor, spec, nil
+}
+
+// Matches 检查发布包是否满足发行商和版本范围
+func (r *Release) Matches(vendor string, spec *version.Spec) bool {
+	if vendor != "" && r.Vendor != vendor {
+		return false
+	}
+	return spec.MatchesString(r.Version)
+}
+
+// Find 在目录中查找满足发行商和版本范围、适用于当前系统的最高版本
+// 请求格式为vendor@spec，例如temurin@21、zulu@17+；省略发行商时匹配任意发行商
+func Find(releases []Release, request string) (*Release, error) {
+	vendor, spec, err := ParseRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *Release
+	for i := range releases {
+		rel := &releases[i]
+		if rel.OS != CurrentOS() || rel.Arch != CurrentArch() {
+			continue
+		}
+		if !rel.Matches(vendor, spec) {
+			continue
+		}
+		if best == nil || version.CompareStrings(rel.Version, best.Version) > 0 {
+			best = rel
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no JDK matching %s found for %s/%s", request, CurrentOS(), CurrentArch())
+	}
+	return best, nil
+}
+
+// CurrentOS 返回目录中使用的当前操作系统名称
+func CurrentOS() string {
+	if runtime.GOOS == "darwin" {
+		return "macos"
+	}
+	return runtime.GOOS
+}
+
+// CurrentArch 返回目录中使用的当前架构名称
+func CurrentArch() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "x64"
+	case "arm64":
+		return "aarch64"
+	case "386":
+		return "x86"
+	}
+	return runtime.GOARCH
+}
+
+// normalizeVendor 将发行商名称转换为统一名称
+func normalizeVendor(vendor string) string {
+	if canonical := detect.CanonicalVendor(vendor); canonical != "" {
+		return canonical
+	}
+	return strings.ToLower(vendor)
+}
@@ -0,0 +1,194 @@
+package install
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// 下载的超时时间：压缩包较大，不限制总时长，
+// 只限制建立连接、等待响应头和两次读取之间的时间，避免网络异常时一直挂起
+const (
+	dialTimeout   = 30 * time.Second
+	headerTimeout = 30 * time.Second
+)
+
+// idleTimeout 两次读取之间的最长等待时间，测试中会缩短
+var idleTimeout = 60 * time.Second
+
+// httpClient 下载使用的HTTP客户端
+var httpClient = &http.Client{Transport: newTransport()}
+
+// newTransport 在默认Transport的基础上设置连接和响应头超时，保留代理等其他设置
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = 10 * time.Second
+	transport.ResponseHeaderTimeout = headerTimeout
+	return transport
+}
+
+// idleReader 超过idleTimeout没有读到数据时取消请求
+type idleReader struct {
+	body   io.ReadCloser
+	timer  *time.Timer
+	cancel context.CancelFunc
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.timer.Reset(idleTimeout)
+	return n, err
+}
+
+func (r *idleReader) Close() error {
+	r.timer.Stop()
+	r.cancel()
+	return r.body.Close()
+}
+
+// open 打开http(s)或file地址
+func open(rawURL string) (io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %s: %w", rawURL, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			cancel()
+			return nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+		}
+		return &idleReader{body: resp.Body, timer: time.AfterFunc(idleTimeout, cancel), cancel: cancel}, nil
+	case "file":
+		return os.Open(fileURLPath(u))
+	case "":
+		// 没有协议时视为本地路径
+		return os.Open(rawURL)
+	}
+	// Windows盘符会被解析为单字母协议
+	if runtime.GOOS == "windows" && len(u.Scheme) == 1 {
+		return os.Open(rawURL)
+	}
+	return nil, fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+}
+
+// fileURLPath 将file地址转换为本地路径
+func fileURLPath(u *url.URL) string {
+	path := u.Path
+	// Windows：file:///C:/jdks/x.zip
+	if runtime.GOOS == "windows" && len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// ErrWeakChecksum 发布包只有SHA-1校验和，而调用方没有允许使用SHA-1
+// SHA-1已经可以构造碰撞，不能可靠地发现被篡改的压缩包
+var ErrWeakChecksum = errors.New("only a SHA-1 checksum is available")
+
+// checksum 返回用于校验的算法和期望值
+// 优先使用SHA-256，其次从校验和文件获取，发行商只提供SHA-1时需要allowSHA1才使用SHA-1
+func checksum(rel *Release, allowSHA1 bool) (algorithm string, expected string, err error) {
+	if rel.SHA256 != "" {
+		return "SHA-256", rel.SHA256, nil
+	}
+	if rel.ChecksumURL != "" {
+		body, err := open(rel.ChecksumURL)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to fetch checksum: %w", err)
+		}
+		defer body.Close()
+
+		content, err := io.ReadAll(io.LimitReader(body, 4096))
+		if err != nil {
+			return "", "", fmt.Errorf("failed to fetch checksum: %w", err)
+		}
+		// 校验和文件格式为"<hash>  <文件名>"或只有hash
+		fields := strings.Fields(string(content))
+		if len(fields) == 0 {
+			return "", "", fmt.Errorf("empty checksum file %s", rel.ChecksumURL)
+		}
+		return "SHA-256", fields[0], nil
+	}
+	if rel.SHA1 != "" {
+		if !allowSHA1 {
+			return "", "", fmt.Errorf("%w for %s %s", ErrWeakChecksum, rel.Vendor, rel.Version)
+		}
+		return "SHA-1", rel.SHA1, nil
+	}
+	return "", "", fmt.Errorf("no checksum available for %s %s", rel.Vendor, rel.Version)
+}
+
+// download 下载文件到dest并校验
+func download(rawURL, dest, algorithm, expected string) error {
+	body, err := open(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	defer body.Close()
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dest, err)
+	}
+	defer file.Close()
+
+	var h hash.Hash = sha256.New()
+	if algorithm == "SHA-1" {
+		h = sha1.New()
+	}
+	if _, err := io.Copy(io.MultiWriter(file, h), body); err != nil {
+		return fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return fmt.Errorf("%s checksum mismatch for %s: expected %s, got %s", algorithm, rawURL, expected, actual)
+	}
+	return nil
+}
+
+// fileSHA256 计算文件的SHA-256校验和
+func fileSHA256(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,375 @@
+package install
+
+import (
+	"archive/tar"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"javaman/internal/config"
+)
+
+// TestMain 将用户主目录指向临时目录并初始化配置，使安装写入~/.javaman/jdks时不影响真实环境
+// 配置使用全局的viper实例，只能初始化一次，所以整个包共用同一个临时目录
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "javaman-install-test-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", home)
+	os.Setenv("USERPROFILE", home) // Windows
+	os.Unsetenv("JAVA_HOME")
+	if err := config.Initialize(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+// jdkDirs 返回安装根目录中的条目名称
+func jdkDirs(t *testing.T) []string {
+	t.Helper()
+	root, err := Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+// fileURL 将本地路径转换为file地址
+func fileURL(name string) string {
+	name = filepath.ToSlash(name)
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name // Windows：C:/x -> /C:/x
+	}
+	return "file://" + name
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// serveFiles 启动按路径返回固定内容的服务器，未知路径返回404
+func serveFiles(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestChecksum(t *testing.T) {
+	const sum = "0a0b7b3f0f7f0e8e5b4e0b0a6d2f6f6e9a3c3e2b1b0c9d8e7f6a5b4c3d2e1f00"
+	srv := serveFiles(t, map[string][]byte{
+		"/with-name.sha256": []byte(sum + "  OpenJDK21U-jdk_x64_linux_hotspot_21.0.1_12.tar.gz\n"),
+		"/hash-only.sha256": []byte(sum + "\n"),
+		"/crlf.sha256":      []byte(sum + " *jdk.zip\r\n"),
+		"/empty.sha256":     []byte("  \n"),
+	})
+
+	tests := []struct {
+		name          string
+		rel           Release
+		allowSHA1     bool
+		wantAlgorithm string
+		wantSum       string
+		wantErr       error // nil表示只检查是否失败
+		fail          bool
+	}{
+		{name: "sha256 field", rel: Release{SHA256: sum, ChecksumURL: srv.URL + "/missing", SHA1: "ab"}, wantAlgorithm: "SHA-256", wantSum: sum},
+		{name: "checksum file with file name", rel: Release{ChecksumURL: srv.URL + "/with-name.sha256"}, wantAlgorithm: "SHA-256", wantSum: sum},
+		{name: "checksum file with hash only", rel: Release{ChecksumURL: srv.URL + "/hash-only.sha256"}, wantAlgorithm: "SHA-256", wantSum: sum},
+		{name: "checksum file with binary marker", rel: Release{ChecksumURL: srv.URL + "/crlf.sha256"}, wantAlgorithm: "SHA-256", wantSum: sum},
+		{name: "empty checksum file", rel: Release{ChecksumURL: srv.URL + "/empty.sha256"}, fail: true},
+		{name: "missing checksum file", rel: Release{ChecksumURL: srv.URL + "/missing"}, fail: true},
+		{name: "sha1 not allowed", rel: Release{SHA1: "ab"}, fail: true, wantErr: ErrWeakChecksum},
+		{name: "sha1 allowed", rel: Release{SHA1: "ab"}, allowSHA1: true, wantAlgorithm: "SHA-1", wantSum: "ab"},
+		{name: "no checksum", rel: Release{}, allowSHA1: true, fail: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algorithm, got, err := checksum(&tt.rel, tt.allowSHA1)
+			if tt.fail {
+				if err == nil {
+					t.Fatalf("checksum() = %s %q, want error", algorithm, got)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("checksum() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checksum() error: %v", err)
+			}
+			if algorithm != tt.wantAlgorithm || got != tt.wantSum {
+				t.Errorf("checksum() = %s %q, want %s %q", algorithm, got, tt.wantAlgorithm, tt.wantSum)
+			}
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	content := []byte("not really a JDK")
+	sum1 := sha1.Sum(content)
+	srv := serveFiles(t, map[string][]byte{"/jdk.tar.gz": content})
+
+	tests := []struct {
+		name      string
+		url       string
+		algorithm string
+		expected  string
+		wantErr   string
+	}{
+		{"sha256", srv.URL + "/jdk.tar.gz", "SHA-256", sha256Hex(content), ""},
+		{"upper case sha256", srv.URL + "/jdk.tar.gz", "SHA-256", strings.ToUpper(sha256Hex(content)), ""},
+		{"sha1", srv.URL + "/jdk.tar.gz", "SHA-1", hex.EncodeToString(sum1[:]), ""},
+		{"sha256 mismatch", srv.URL + "/jdk.tar.gz", "SHA-256", sha256Hex([]byte("other")), "checksum mismatch"},
+		{"not found", srv.URL + "/missing.tar.gz", "SHA-256", sha256Hex(content), "404 Not Found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+			err := download(tt.url, dest, tt.algorithm, tt.expected)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("download() error: %v", err)
+				}
+				if got, _ := os.ReadFile(dest); string(got) != string(content) {
+					t.Errorf("downloaded content = %q, want %q", got, content)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("download() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDownloadServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	err := download(srv.URL+"/jdk.tar.gz", dest, "SHA-256", sha256Hex(nil))
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("download() error = %v, want the 503 status", err)
+	}
+	// 响应失败时不应创建目标文件
+	if _, err := os.Stat(dest); err == nil {
+		t.Errorf("%s was created for a failed response", dest)
+	}
+}
+
+func TestDownloadIdleTimeout(t *testing.T) {
+	saved := idleTimeout
+	idleTimeout = 50 * time.Millisecond
+	defer func() { idleTimeout = saved }()
+
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 先发送一部分数据，之后不再发送，模拟中途卡住的连接
+		w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	dest := filepath.Join(t.TempDir(), "archive.tar.gz")
+	result := make(chan error, 1)
+	go func() {
+		result <- download(srv.URL+"/jdk.tar.gz", dest, "SHA-256", sha256Hex(nil))
+	}()
+	select {
+	case err := <-result:
+		if err == nil {
+			t.Error("download() succeeded on a stalled connection, want error")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("download() did not time out on a stalled connection")
+	}
+}
+
+func TestIdleReaderResetsOnRead(t *testing.T) {
+	saved := idleTimeout
+	idleTimeout = 100 * time.Millisecond
+	defer func() { idleTimeout = saved }()
+
+	// 每次发送之间的间隔小于idleTimeout，总时长超过idleTimeout，下载应成功
+	chunks := 5
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < chunks; i++ {
+			w.Write([]byte("chunk"))
+			w.(http.Flusher).Flush()
+			time.Sleep(40 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	body, err := open(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+	if want := strings.Repeat("chunk", chunks); string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+}
+
+func TestParseCatalogRelativeURLs(t *testing.T) {
+	catalog := []byte(`{"jdks": [
+		{"vendor": "Temurin", "version": "21.0.1+12", "os": "linux", "arch": "x64",
+		 "url": "temurin/jdk-21.tar.gz", "sha256_url": "temurin/jdk-21.tar.gz.sha256"},
+		{"vendor": "zulu", "version": "17.0.9", "os": "linux", "arch": "x64",
+		 "url": "../shared/zulu-17.tar.gz", "sha256": "ab"},
+		{"vendor": "corretto", "version": "21.0.1", "os": "linux", "arch": "x64",
+		 "url": "https://corretto.aws/downloads/corretto-21.tar.gz", "sha256": "cd"}
+	]}`)
+
+	tests := []struct {
+		catalogURL  string
+		wantURLs    []string
+		wantSumURL0 string
+	}{
+		{
+			"file:///srv/mirror/java/catalog.json",
+			[]string{"file:///srv/mirror/java/temurin/jdk-21.tar.gz", "file:///srv/mirror/shared/zulu-17.tar.gz", "https://corretto.aws/downloads/corretto-21.tar.gz"},
+			"file:///srv/mirror/java/temurin/jdk-21.tar.gz.sha256",
+		},
+		{
+			"http://mirror.example.com/java/catalog.json",
+			[]string{"http://mirror.example.com/java/temurin/jdk-21.tar.gz", "http://mirror.example.com/shared/zulu-17.tar.gz", "https://corretto.aws/downloads/corretto-21.tar.gz"},
+			"http://mirror.example.com/java/temurin/jdk-21.tar.gz.sha256",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.catalogURL, func(t *testing.T) {
+			releases, err := ParseCatalog(tt.catalogURL, catalog)
+			if err != nil {
+				t.Fatalf("ParseCatalog() error: %v", err)
+			}
+			if len(releases) != len(tt.wantURLs) {
+				t.Fatalf("ParseCatalog() returned %d releases, want %d", len(releases), len(tt.wantURLs))
+			}
+			for i, want := range tt.wantURLs {
+				if releases[i].URL != want {
+					t.Errorf("URL[%d] = %q, want %q", i, releases[i].URL, want)
+				}
+			}
+			if releases[0].ChecksumURL != tt.wantSumURL0 {
+				t.Errorf("ChecksumURL = %q, want %q", releases[0].ChecksumURL, tt.wantSumURL0)
+			}
+			if releases[0].Vendor != "temurin" {
+				t.Errorf("Vendor = %q, want temurin", releases[0].Vendor)
+			}
+		})
+	}
+}
+
+// writeJDKArchive 生成包含release文件和bin/java的最小JDK压缩包，返回内容
+func writeJDKArchive(t *testing.T, dir string) []byte {
+	t.Helper()
+	archive := filepath.Join(dir, "jdk.tar.gz")
+	writeTarGz(t, archive, []entry{
+		{"jdk-17.0.9+9/", tar.TypeDir, ""},
+		{"jdk-17.0.9+9/bin/", tar.TypeDir, ""},
+		{"jdk-17.0.9+9/bin/java", tar.TypeReg, "java"},
+		{"jdk-17.0.9+9/bin/java.exe", tar.TypeReg, "java"},
+		{"jdk-17.0.9+9/release", tar.TypeReg, "IMPLEMENTOR=\"Eclipse Adoptium\"\nJAVA_VERSION=\"17.0.9\"\n"},
+	})
+	content, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestInstallFromFileCatalog(t *testing.T) {
+	mirror := t.TempDir()
+	content := writeJDKArchive(t, mirror)
+	if err := os.WriteFile(filepath.Join(mirror, "jdk.tar.gz.sha256"), []byte(sha256Hex(content)+"  jdk.tar.gz\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	catalog := `{"jdks": [{"vendor": "temurin", "version": "17.0.9+9", "os": "linux", "arch": "x64",
+		"url": "jdk.tar.gz", "sha256_url": "jdk.tar.gz.sha256"}]}`
+	if err := os.WriteFile(filepath.Join(mirror, "catalog.json"), []byte(catalog), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	releases, err := FetchCatalog(fileURL(filepath.Join(mirror, "catalog.json")))
+	if err != nil {
+		t.Fatalf("FetchCatalog() error: %v", err)
+	}
+	id, jdkPath, err := Install(&releases[0], false, io.Discard)
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	root, _ := Root()
+	t.Cleanup(func() { os.RemoveAll(filepath.Join(root, id)) })
+	if want := filepath.Join(root, id); jdkPath != want {
+		t.Errorf("Install() path = %q, want %q", jdkPath, want)
+	}
+	manifest, err := ReadManifest(jdkPath)
+	if err != nil {
+		t.Fatalf("ReadManifest() error: %v", err)
+	}
+	if manifest.SHA256 != sha256Hex(content) || manifest.Source != releases[0].URL {
+		t.Errorf("manifest = %+v, want sha256 %s from %s", manifest, sha256Hex(content), releases[0].URL)
+	}
+}
+
+func TestInstallChecksumMismatch(t *testing.T) {
+	content := writeJDKArchive(t, t.TempDir())
+	srv := serveFiles(t, map[string][]byte{"/jdk.tar.gz": content})
+	before := jdkDirs(t)
+
+	rel := &Release{Vendor: "temurin", Version: "17.0.9+9", OS: "linux", Arch: "x64",
+		ArchiveType: "tar.gz", URL: srv.URL + "/jdk.tar.gz", SHA256: sha256Hex([]byte("tampered"))}
+	if _, _, err := Install(rel, false, io.Discard); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Install() error = %v, want checksum mismatch", err)
+	}
+
+	// 校验失败时不能留下安装目录或临时文件
+	if after := jdkDirs(t); !reflect.DeepEqual(after, before) {
+		t.Errorf("jdks directory = %v after a checksum mismatch, want %v", after, before)
+	}
+}
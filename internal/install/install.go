@@ -0,0 +1,247 @@
+package install
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"javaman/internal/config"
+	"javaman/internal/detect"
+)
+
+const (
+	jdksDirName  = "jdks"
+	manifestName = ".javaman-install.json"
+)
+
+// Manifest 记录由javaman安装的JDK的来源，保存在安装目录中
+type Manifest struct {
+	ID          string    `json:"id"`
+	Vendor      string    `json:"vendor,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	Source      string    `json:"source"`
+	SHA256      string    `json:"sha256,omitempty"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// Root 返回javaman安装JDK的根目录（~/.javaman/jdks）
+func Root() (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, jdksDirName), nil
+}
+
+// ReadManifest 读取安装目录中的安装记录
+func ReadManifest(jdkPath string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(jdkPath, manifestName))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse install manifest in %s: %w", jdkPath, err)
+	}
+	return &m, nil
+}
+
+// Install 下载、校验并解压发布包到~/.javaman/jdks/<id>，返回标识和安装目录
+// 发布包只有SHA-1校验和时，allowSHA1为false返回ErrWeakChecksum
+// 进度信息写入out；安装目录不会注册到配置中
+func Install(rel *Release, allowSHA1 bool, out io.Writer) (id string, jdkPath string, err error) {
+	if _, exists := config.GetVersions()[rel.ID()]; exists {
+		return "", "", fmt.Errorf("version %s is already installed", rel.ID())
+	}
+	algorithm, expected, err := checksum(rel, allowSHA1)
+	if err != nil {
+		return "", "", err
+	}
+	if algorithm == "SHA-1" {
+		fmt.Fprintf(out, "Warning: %s %s only provides a SHA-1 checksum, which cannot reliably detect a tampered archive\n", rel.Vendor, rel.Version)
+	}
+	kind, err := archiveType(rel.ArchiveType, path.Base(rel.URL))
+	if err != nil {
+		return "", "", err
+	}
+
+	root, err := Root()
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create %s: %w", root, err)
+	}
+
+	// 在安装根目录下使用临时目录，完成后直接重命名
+	work, err := os.MkdirTemp(root, ".install-")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(work)
+
+	archive := filepath.Join(work, "archive."+kind)
+	fmt.Fprintf(out, "Downloading %s\n", rel.URL)
+	if err := download(rel.URL, archive, algorithm, expected); err != nil {
+		return "", "", err
+	}
+	fmt.Fprintf(out, "Verified %s %s\n", algorithm, expected)
+
+	sum := expected
+	if algorithm != "SHA-256" {
+		if sum, err = fileSHA256(archive); err != nil {
+			return "", "", err
+		}
+	}
+	return unpack(archive, kind, work, rel.ID(), Manifest{
+		Vendor:  rel.Vendor,
+		Version: rel.Version,
+		Source:  rel.URL,
+		SHA256:  sum,
+	}, out)
+}
+
+// InstallArchive 从本地压缩包安装JDK，返回标识和安装目录
+// 标识根据release文件生成，没有release文件时使用压缩包文件名
+func InstallArchive(archive string, out io.Writer) (id string, jdkPath string, err error) {
+	archive, err = filepath.Abs(archive)
+	if err != nil {
+		return "", "", err
+	}
+	kind, err := archiveType("", archive)
+	if err != nil {
+		return "", "", err
+	}
+	sum, err := fileSHA256(archive)
+	if err != nil {
+		return "", "", err
+	}
+
+	root, err := Root()
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create %s: %w", root, err)
+	}
+	work, err := os.MkdirTemp(root, ".install-")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(work)
+
+	// 没有release文件时使用去掉扩展名的文件名
+	name := strings.ToLower(filepath.Base(archive))
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	return unpack(archive, kind, work, name, Manifest{
+		Source: archive,
+		SHA256: sum,
+	}, out)
+}
+
+// unpack 解压压缩包并将JDK移动到安装目录
+func unpack(archive, kind, work, fallbackID string, manifest Manifest, out io.Writer) (string, string, error) {
+	root, err := Root()
+	if err != nil {
+		return "", "", err
+	}
+
+	extracted := filepath.Join(work, "extracted")
+	if err := os.MkdirAll(extracted, 0755); err != nil {
+		return "", "", err
+	}
+	fmt.Fprintf(out, "Extracting %s\n", filepath.Base(archive))
+	if err := extract(archive, kind, extracted); err != nil {
+		return "", "", err
+	}
+
+	top, home, err := findJDKRoot(extracted)
+	if err != nil {
+		return "", "", err
+	}
+
+	// 根据release文件生成标识
+	info, _ := detect.ParseRelease(filepath.Join(top, home))
+	id := detect.JDKID(info, fallbackID)
+	// 标识来自压缩包中的release文件，必须确保不会把JDK移动到安装目录之外
+	if !detect.ValidID(id) {
+		return "", "", fmt.Errorf("invalid JDK ID %q derived from archive", id)
+	}
+	if _, exists := config.GetVersions()[id]; exists {
+		return "", "", fmt.Errorf("version %s is already installed", id)
+	}
+
+	dest := filepath.Join(root, id)
+	if _, err := os.Lstat(dest); err == nil {
+		return "", "", fmt.Errorf("install directory %s already exists", dest)
+	}
+	if err := os.Rename(top, dest); err != nil {
+		return "", "", fmt.Errorf("failed to move JDK to %s: %w", dest, err)
+	}
+	jdkPath := filepath.Join(dest, home)
+
+	manifest.ID = id
+	if manifest.Vendor == "" && info != nil {
+		manifest.Vendor, manifest.Version = info.Vendor, info.FullVersion()
+	}
+	manifest.InstalledAt = time.Now().UTC()
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(filepath.Join(jdkPath, manifestName), content, 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write install manifest: %w", err)
+	}
+
+	return id, jdkPath, nil
+}
+
+// findJDKRoot 在解压目录中查找JDK，返回需要移动到安装目录的顶层目录和JDK主目录的相对路径
+// 压缩包通常只有一个顶层目录，例如jdk-21.0.1+12/；macOS的压缩包中JDK位于
+// jdk-21.0.1+12/Contents/Home，此时移动整个bundle以保留Contents中的其他文件
+func findJDKRoot(dir string) (top string, home string, err error) {
+	for depth := 0; depth < 4; depth++ {
+		if hasJava(dir) {
+			return dir, "", nil
+		}
+		macHome := filepath.Join("Contents", "Home")
+		if hasJava(filepath.Join(dir, macHome)) {
+			return dir, macHome, nil
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return "", "", err
+		}
+		var subdirs []string
+		for _, entry := range entries {
+			if entry.IsDir() {
+				subdirs = append(subdirs, entry.Name())
+			}
+		}
+		if len(subdirs) != 1 {
+			break
+		}
+		dir = filepath.Join(dir, subdirs[0])
+	}
+	return "", "", fmt.Errorf("archive does not contain a JDK (bin/java not found)")
+}
+
+// hasJava 检查目录中是否有bin/java
+func hasJava(dir string) bool {
+	java := filepath.Join(dir, "bin", "java")
+	if runtime.GOOS == "windows" {
+		java += ".exe"
+	}
+	info, err := os.Stat(java)
+	return err == nil && !info.IsDir()
+}
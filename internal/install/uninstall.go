@@ -0,0 +1,56 @@
+package install
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InstallDir 返回由javaman安装的JDK的安装目录（~/.javaman/jdks/<id>）
+// JDK必须位于安装根目录内并且有安装记录，否则返回错误，以免删除手动添加的系统JDK
+func InstallDir(jdkPath string) (string, error) {
+	root, err := Root()
+	if err != nil {
+		return "", err
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("%s was not installed by javaman", jdkPath)
+	}
+	realPath, err := filepath.EvalSymlinks(jdkPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", jdkPath, err)
+	}
+
+	rel, err := filepath.Rel(realRoot, realPath)
+	if err != nil || rel == "." || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%s is not under %s and was not installed by javaman", jdkPath, root)
+	}
+	// macOS的JDK位于<id>/Contents/Home，删除整个<id>目录
+	top, _, _ := strings.Cut(rel, string(filepath.Separator))
+	dir := filepath.Join(realRoot, top)
+	if strings.HasPrefix(top, ".") {
+		return "", fmt.Errorf("%s is not an installed JDK", jdkPath)
+	}
+	if info, err := os.Lstat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%s is not an installed JDK", jdkPath)
+	}
+
+	if _, err := ReadManifest(realPath); err != nil {
+		return "", fmt.Errorf("%s has no javaman install manifest, refusing to delete it", jdkPath)
+	}
+	return dir, nil
+}
+
+// Uninstall 删除由javaman安装的JDK
+func Uninstall(jdkPath string) error {
+	dir, err := InstallDir(jdkPath)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", dir, err)
+	}
+	return nil
+}
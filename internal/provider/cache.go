@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"javaman/internal/config"
+	"javaman/internal/install"
+)
+
+// CacheTTL 远程元数据缓存的有效期
+const CacheTTL = 24 * time.Hour
+
+// cacheFile 缓存文件格式
+type cacheFile struct {
+	FetchedAt time.Time         `json:"fetched_at"`
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	Releases  []install.Release `json:"releases"`
+}
+
+// Result 获取发布包列表的结果
+type Result struct {
+	Releases  []install.Release
+	FetchedAt time.Time
+	Stale     bool  // 获取失败，使用了过期的缓存
+	Err       error // 获取失败的原因，Stale为true时仍有可用数据
+}
+
+// CacheDir 返回远程元数据缓存目录（~/.javaman/cache/remote）
+func CacheDir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache", "remote"), nil
+}
+
+// Fetch 获取发行版来源的发布包列表，缓存未过期时直接使用缓存
+// refresh为true时忽略缓存；获取失败时回退到过期的缓存，以便离线使用
+func Fetch(p Provider, osName, arch string, refresh bool) Result {
+	if _, ok := p.(*catalog); ok {
+		releases, err := p.Releases(osName, arch)
+		sortReleases(releases)
+		return Result{Releases: releases, FetchedAt: time.Now(), Err: err}
+	}
+
+	cached, cacheErr := readCache(p.Name())
+	usable := cacheErr == nil && cached.OS == osName && cached.Arch == arch
+	if usable && !refresh && time.Since(cached.FetchedAt) < CacheTTL {
+		return Result{Releases: cached.Releases, FetchedAt: cached.FetchedAt}
+	}
+
+	releases, err := p.Releases(osName, arch)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", p.Name(), err)
+		if usable {
+			return Result{Releases: cached.Releases, FetchedAt: cached.FetchedAt, Stale: true, Err: err}
+		}
+		return Result{Err: err}
+	}
+	sortReleases(releases)
+
+	now := time.Now()
+	// 缓存写入失败不影响结果
+	_ = writeCache(p.Name(), &cacheFile{FetchedAt: now, OS: osName, Arch: arch, Releases: releases})
+	return Result{Releases: releases, FetchedAt: now}
+}
+
+// readCache 读取发行版来源的缓存
+func readCache(name string) (*cacheFile, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var c cacheFile
+	if err := json.Unmarshal(content, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// writeCache 写入发行版来源的缓存，先写临时文件再重命名，避免留下不完整的缓存
+func writeCache(name string, c *cacheFile) error {
+	dir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name+".json"))
+}
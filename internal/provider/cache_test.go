@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"javaman/internal/install"
+)
+
+// fakeProvider 记录调用次数的发行版来源，err不为空时获取失败
+type fakeProvider struct {
+	releases []install.Release
+	err      error
+	calls    int
+}
+
+func (f *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (f *fakeProvider) Releases(os, arch string) ([]install.Release, error) {
+	f.calls++
+	return f.releases, f.err
+}
+
+// tempHome 将用户主目录指向临时目录，使缓存写入~/.javaman/cache/remote时不影响真实环境
+func tempHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // Windows
+}
+
+func TestFetch(t *testing.T) {
+	cached := []install.Release{{Vendor: "fake", Version: "17.0.8+7", OS: "linux", Arch: "x64"}}
+	fresh := []install.Release{{Vendor: "fake", Version: "17.0.9+9", OS: "linux", Arch: "x64"}}
+	fetchErr := errors.New("network unreachable")
+
+	tests := []struct {
+		name      string
+		cache     *cacheFile // 预先写入的缓存，nil表示没有缓存
+		refresh   bool
+		err       error // 来源返回的错误
+		wantCalls int
+		want      []install.Release
+		wantStale bool
+		wantErr   bool
+	}{
+		{
+			name:      "no cache",
+			wantCalls: 1,
+			want:      fresh,
+		},
+		{
+			name:      "fresh cache",
+			cache:     &cacheFile{FetchedAt: time.Now().Add(-time.Hour), OS: "linux", Arch: "x64", Releases: cached},
+			wantCalls: 0,
+			want:      cached,
+		},
+		{
+			name:      "expired cache",
+			cache:     &cacheFile{FetchedAt: time.Now().Add(-CacheTTL - time.Minute), OS: "linux", Arch: "x64", Releases: cached},
+			wantCalls: 1,
+			want:      fresh,
+		},
+		{
+			name:      "refresh ignores fresh cache",
+			cache:     &cacheFile{FetchedAt: time.Now(), OS: "linux", Arch: "x64", Releases: cached},
+			refresh:   true,
+			wantCalls: 1,
+			want:      fresh,
+		},
+		{
+			name:      "cache for another platform",
+			cache:     &cacheFile{FetchedAt: time.Now(), OS: "macos", Arch: "aarch64", Releases: cached},
+			wantCalls: 1,
+			want:      fresh,
+		},
+		{
+			name:      "failure falls back to expired cache",
+			cache:     &cacheFile{FetchedAt: time.Now().Add(-2 * CacheTTL), OS: "linux", Arch: "x64", Releases: cached},
+			err:       fetchErr,
+			wantCalls: 1,
+			want:      cached,
+			wantStale: true,
+			wantErr:   true,
+		},
+		{
+			name:      "refresh failure falls back to cache",
+			cache:     &cacheFile{FetchedAt: time.Now(), OS: "linux", Arch: "x64", Releases: cached},
+			refresh:   true,
+			err:       fetchErr,
+			wantCalls: 1,
+			want:      cached,
+			wantStale: true,
+			wantErr:   true,
+		},
+		{
+			name:      "failure without cache",
+			err:       fetchErr,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "failure ignores cache for another platform",
+			cache:     &cacheFile{FetchedAt: time.Now(), OS: "windows", Arch: "x64", Releases: cached},
+			err:       fetchErr,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempHome(t)
+			if tt.cache != nil {
+				if err := writeCache("fake", tt.cache); err != nil {
+					t.Fatal(err)
+				}
+			}
+			p := &fakeProvider{releases: fresh, err: tt.err}
+
+			result := Fetch(p, "linux", "x64", tt.refresh)
+			if p.calls != tt.wantCalls {
+				t.Errorf("Releases() called %d times, want %d", p.calls, tt.wantCalls)
+			}
+			if len(result.Releases) != len(tt.want) || (len(tt.want) > 0 && result.Releases[0].Version != tt.want[0].Version) {
+				t.Errorf("Fetch() releases = %+v, want %+v", result.Releases, tt.want)
+			}
+			if result.Stale != tt.wantStale {
+				t.Errorf("Fetch() stale = %v, want %v", result.Stale, tt.wantStale)
+			}
+			if (result.Err != nil) != tt.wantErr {
+				t.Errorf("Fetch() error = %v, want error %v", result.Err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(result.Err, fetchErr) {
+				t.Errorf("Fetch() error = %v, want it to wrap %v", result.Err, fetchErr)
+			}
+		})
+	}
+}
+
+func TestFetchWritesCache(t *testing.T) {
+	tempHome(t)
+	p := &fakeProvider{releases: []install.Release{
+		{Vendor: "fake", Version: "17.0.9+9"},
+		{Vendor: "fake", Version: "21.0.1+12"},
+	}}
+
+	first := Fetch(p, "linux", "x64", false)
+	if first.Err != nil {
+		t.Fatalf("Fetch() error: %v", first.Err)
+	}
+	// 写入缓存前按版本排序，高版本在前
+	if first.Releases[0].Version != "21.0.1+12" {
+		t.Errorf("Fetch() first release = %q, want 21.0.1+12", first.Releases[0].Version)
+	}
+
+	// 第二次获取使用刚写入的缓存，不再访问来源
+	p.err = errors.New("should not be called")
+	second := Fetch(p, "linux", "x64", false)
+	if p.calls != 1 {
+		t.Errorf("Releases() called %d times, want 1", p.calls)
+	}
+	if second.Err != nil || len(second.Releases) != 2 || !second.FetchedAt.Equal(first.FetchedAt) {
+		t.Errorf("Fetch() from cache = %+v, want the cached releases from %v", second, first.FetchedAt)
+	}
+}
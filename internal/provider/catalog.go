@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"javaman/internal/install"
+)
+
+// catalog 用户配置的发布包目录（settings.catalog_url），通常是内网镜像
+// 目录可以包含多个发行商的发布包，不写入缓存
+type catalog struct {
+	url string
+}
+
+// NewCatalog 创建使用发布包目录的发行版来源
+func NewCatalog(url string) Provider {
+	return &catalog{url: url}
+}
+
+func (c *catalog) Name() string {
+	return "catalog"
+}
+
+func (c *catalog) Releases(osName, arch string) ([]install.Release, error) {
+	all, err := install.FetchCatalog(c.url)
+	if err != nil {
+		return nil, err
+	}
+	var releases []install.Release
+	for _, rel := range all {
+		if rel.OS != osName || rel.Arch != arch {
+			continue
+		}
+		if rel.ArchiveType == "" {
+			rel.ArchiveType = archiveOf(rel.URL)
+		}
+		releases = append(releases, rel)
+	}
+	return releases, nil
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"path"
+	"strings"
+
+	"javaman/internal/install"
+)
+
+// corretto Amazon Corretto，使用corretto-downloads仓库发布的最新版本索引
+type corretto struct {
+	indexURL string
+}
+
+// correttoFile 索引中的下载文件
+type correttoFile struct {
+	ChecksumSHA256 string `json:"checksum_sha256"`
+	Resource       string `json:"resource"`
+}
+
+// correttoIndex 索引格式：os -> arch -> 类型 -> 主版本 -> 压缩格式 -> 文件
+type correttoIndex map[string]map[string]map[string]map[string]map[string]correttoFile
+
+func (c *corretto) Name() string {
+	return "corretto"
+}
+
+func (c *corretto) Releases(osName, arch string) ([]install.Release, error) {
+	var index correttoIndex
+	if err := getJSON(c.indexURL, &index); err != nil {
+		return nil, err
+	}
+
+	var releases []install.Release
+	for _, formats := range index[osName][arch]["jdk"] {
+		for format, file := range formats {
+			kind := archiveOf("x." + format)
+			if kind == "" || file.Resource == "" {
+				continue
+			}
+			// 资源路径格式：/downloads/resources/21.0.1.12.1/amazon-corretto-21.0.1.12.1-linux-x64.tar.gz
+			version := correttoVersion(path.Base(path.Dir(file.Resource)))
+			if version == "" {
+				continue
+			}
+			releases = append(releases, install.Release{
+				Vendor:      c.Name(),
+				Version:     version,
+				OS:          osName,
+				Arch:        arch,
+				ArchiveType: kind,
+				URL:         "https://corretto.aws" + file.Resource,
+				SHA256:      file.ChecksumSHA256,
+			})
+		}
+	}
+	return releases, nil
+}
+
+// correttoVersion 将Corretto版本号转换为OpenJDK格式
+// 21.0.1.12.1 -> 21.0.1+12，8.392.08.1 -> 8.0.392+8
+func correttoVersion(raw string) string {
+	parts := strings.Split(raw, ".")
+	if len(parts) < 4 {
+		return ""
+	}
+	if parts[0] == "8" {
+		return "8.0." + parts[1] + "+" + strings.TrimLeft(parts[2], "0")
+	}
+	return parts[0] + "." + parts[1] + "." + parts[2] + "+" + strings.TrimLeft(parts[3], "0")
+}
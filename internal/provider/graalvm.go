@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"strings"
+
+	"javaman/internal/install"
+)
+
+// graalvm GraalVM Community Edition，使用graalvm-ce-builds仓库的GitHub发布
+type graalvm struct {
+	baseURL string
+}
+
+// githubRelease GitHub发布API的响应条目
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func (g *graalvm) Name() string {
+	return "graalvm-ce"
+}
+
+func (g *graalvm) Releases(osName, arch string) ([]install.Release, error) {
+	var entries []githubRelease
+	if err := getJSON(g.baseURL+"/repos/graalvm/graalvm-ce-builds/releases?per_page=100", &entries); err != nil {
+		return nil, err
+	}
+
+	// 文件名格式：graalvm-community-jdk-21.0.1_linux-x64_bin.tar.gz
+	platform := "_" + osName + "-" + arch + "_bin."
+
+	var releases []install.Release
+	for _, entry := range entries {
+		// 旧版本的标签为vm-22.3.0，不使用JDK版本号，跳过
+		version, ok := strings.CutPrefix(entry.TagName, "jdk-")
+		if entry.Prerelease || !ok {
+			continue
+		}
+		for _, asset := range entry.Assets {
+			kind := archiveOf(asset.Name)
+			if kind == "" || !strings.Contains(asset.Name, platform) {
+				continue
+			}
+			releases = append(releases, install.Release{
+				Vendor:      g.Name(),
+				Version:     version,
+				OS:          osName,
+				Arch:        arch,
+				ArchiveType: kind,
+				URL:         asset.BrowserDownloadURL,
+				ChecksumURL: asset.BrowserDownloadURL + ".sha256",
+			})
+		}
+	}
+	return releases, nil
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"net/url"
+	"strings"
+
+	"javaman/internal/install"
+)
+
+// liberica BellSoft Liberica，使用BellSoft API v1
+type liberica struct {
+	baseURL string
+}
+
+// libericaRelease /v1/liberica/releases的响应条目
+type libericaRelease struct {
+	Version     string `json:"version"`
+	DownloadURL string `json:"downloadUrl"`
+	Filename    string `json:"filename"`
+	SHA1        string `json:"sha1"`
+	GA          bool   `json:"GA"`
+}
+
+func (l *liberica) Name() string {
+	return "liberica"
+}
+
+func (l *liberica) Releases(osName, arch string) ([]install.Release, error) {
+	// BellSoft使用x86/arm加位数表示架构
+	apiArch, bitness := "x86", "64"
+	switch arch {
+	case "aarch64":
+		apiArch = "arm"
+	case "x86":
+		bitness = "32"
+	}
+	packageType := "tar.gz"
+	if osName == "windows" {
+		packageType = "zip"
+	}
+
+	query := url.Values{
+		"os":               {osName},
+		"arch":             {apiArch},
+		"bitness":          {bitness},
+		"package-type":     {packageType},
+		"bundle-type":      {"jdk"},
+		"version-modifier": {"latest"},
+	}
+	var entries []libericaRelease
+	if err := getJSON(l.baseURL+"/v1/liberica/releases?"+query.Encode(), &entries); err != nil {
+		return nil, err
+	}
+
+	var releases []install.Release
+	for _, entry := range entries {
+		if !entry.GA || entry.Version == "" || archiveOf(entry.Filename) == "" {
+			continue
+		}
+		// BellSoft只提供SHA-1校验和，安装时需要用户明确允许
+		releases = append(releases, install.Release{
+			Vendor:      l.Name(),
+			Version:     libericaVersion(entry.Version),
+			OS:          osName,
+			Arch:        arch,
+			ArchiveType: packageType,
+			URL:         entry.DownloadURL,
+			SHA1:        entry.SHA1,
+		})
+	}
+	return releases, nil
+}
+
+// libericaVersion 将JDK 8的版本号转换为OpenJDK格式，8u392+9 -> 8.0.392+9
+func libericaVersion(raw string) string {
+	if update, ok := strings.CutPrefix(raw, "8u"); ok {
+		return "8.0." + update
+	}
+	return raw
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"net/url"
+
+	"javaman/internal/install"
+)
+
+// microsoft Microsoft Build of OpenJDK
+// 微软没有提供元数据API，使用foojay Disco API列出版本，校验和从aka.ms的.sha256sum.txt获取
+type microsoft struct {
+	baseURL string
+}
+
+// discoPackages foojay Disco API /disco/v3.0/packages的响应
+type discoPackages struct {
+	Result []struct {
+		ArchiveType       string `json:"archive_type"`
+		JavaVersion       string `json:"java_version"`
+		Filename          string `json:"filename"`
+		DirectDownloadURI string `json:"direct_download_uri"`
+	} `json:"result"`
+}
+
+func (m *microsoft) Name() string {
+	return "microsoft"
+}
+
+func (m *microsoft) Releases(osName, arch string) ([]install.Release, error) {
+	archiveType := "tar.gz"
+	if osName == "windows" {
+		archiveType = "zip"
+	}
+
+	query := url.Values{
+		"distribution":     {"microsoft"},
+		"operating_system": {osName},
+		"architecture":     {arch},
+		"archive_type":     {archiveType},
+		"package_type":     {"jdk"},
+		"release_status":   {"ga"},
+		"latest":           {"available"},
+	}
+	var packages discoPackages
+	if err := getJSON(m.baseURL+"/disco/v3.0/packages?"+query.Encode(), &packages); err != nil {
+		return nil, err
+	}
+
+	var releases []install.Release
+	for _, pkg := range packages.Result {
+		if pkg.JavaVersion == "" || archiveOf(pkg.Filename) == "" {
+			continue
+		}
+		releases = append(releases, install.Release{
+			Vendor:      m.Name(),
+			Version:     pkg.JavaVersion,
+			OS:          osName,
+			Arch:        arch,
+			ArchiveType: archiveType,
+			URL:         pkg.DirectDownloadURI,
+			ChecksumURL: pkg.DirectDownloadURI + ".sha256sum.txt",
+		})
+	}
+	return releases, nil
+}
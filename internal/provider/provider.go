@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"javaman/internal/install"
+	"javaman/internal/version"
+)
+
+// Provider JDK发行版元数据来源
+// 每个发行商的元数据格式不同，由Provider统一转换为install.Release
+type Provider interface {
+	// Name 返回来源名称，内置来源与JDK标识中的发行商一致，例如temurin
+	Name() string
+	// Releases 返回指定系统和架构可下载的发布包
+	// os和arch使用install包中的名称（linux、macos、windows；x64、aarch64、x86）
+	Releases(os, arch string) ([]install.Release, error)
+}
+
+// providers 内置的发行版来源
+var providers = []Provider{
+	&temurin{baseURL: "https://api.adoptium.net"},
+	&zulu{baseURL: "https://api.azul.com"},
+	&corretto{indexURL: "https://corretto.github.io/corretto-downloads/latest_links/indexmap_with_checksum.json"},
+	&liberica{baseURL: "https://api.bell-sw.com"},
+	&microsoft{baseURL: "https://api.foojay.io"},
+	&graalvm{baseURL: "https://api.github.com"},
+}
+
+// All 返回所有内置的发行版来源
+func All() []Provider {
+	return providers
+}
+
+// Get 根据发行商名称获取发行版来源
+func Get(name string) (Provider, bool) {
+	for _, p := range providers {
+		if p.Name() == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Names 返回所有内置发行商名称
+func Names() []string {
+	names := make([]string, 0, len(providers))
+	for _, p := range providers {
+		names = append(names, p.Name())
+	}
+	return names
+}
+
+// httpClient 获取元数据使用的HTTP客户端
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// getJSON 获取并解析JSON格式的元数据
+func getJSON(url string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "javaman")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", url, err)
+	}
+	return nil
+}
+
+// archiveOf 根据文件名判断压缩格式，不支持的格式（msi、pkg等）返回空字符串
+func archiveOf(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(lower, ".zip"):
+		return "zip"
+	}
+	return ""
+}
+
+// sortReleases 按发行商和版本排序，版本高的在前
+func sortReleases(releases []install.Release) {
+	sort.SliceStable(releases, func(i, j int) bool {
+		if releases[i].Vendor != releases[j].Vendor {
+			return releases[i].Vendor < releases[j].Vendor
+		}
+		return version.CompareStrings(releases[i].Version, releases[j].Version) > 0
+	})
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"javaman/internal/install"
+)
+
+// route 测试服务器返回的一个固定响应
+type route struct {
+	path  string            // 请求路径，不含查询参数
+	file  string            // testdata中记录的响应
+	query map[string]string // 请求必须带有的查询参数
+}
+
+// serve 启动按路径返回testdata中记录响应的服务器，未知路径返回404
+func serve(t *testing.T, routes []route) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, rt := range routes {
+			if r.URL.Path != rt.path {
+				continue
+			}
+			for key, want := range rt.query {
+				if got := r.URL.Query().Get(key); got != want {
+					t.Errorf("%s: query %s = %q, want %q", r.URL.Path, key, got, want)
+				}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			http.ServeFile(w, r, "testdata/"+rt.file)
+			return
+		}
+		t.Errorf("unexpected request %s", r.URL)
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProviders(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider func(baseURL string) Provider
+		os, arch string
+		routes   []route
+		want     []install.Release
+	}{
+		{
+			name:     "temurin",
+			provider: func(baseURL string) Provider { return &temurin{baseURL: baseURL} },
+			os:       "macos", arch: "x64",
+			routes: []route{
+				{path: "/v3/info/available_releases", file: "temurin_available_releases.json"},
+				{path: "/v3/assets/latest/17/hotspot", file: "temurin_assets_17.json", query: map[string]string{"os": "mac", "architecture": "x64", "image_type": "jdk"}},
+				{path: "/v3/assets/latest/21/hotspot", file: "temurin_assets_21.json", query: map[string]string{"os": "mac", "architecture": "x64", "image_type": "jdk"}},
+			},
+			want: []install.Release{
+				{Vendor: "temurin", Version: "21.0.1+12", OS: "macos", Arch: "x64", ArchiveType: "tar.gz",
+					URL:    "https://github.com/adoptium/temurin21-binaries/releases/download/jdk-21.0.1%2B12/OpenJDK21U-jdk_x64_mac_hotspot_21.0.1_12.tar.gz",
+					SHA256: "9f6c6ad4a8f6cf3ae8ab3e0fdd4d8a9cfa6ff1b2e5e2b1e4a3c9d2e8f1a7b6c5"},
+				{Vendor: "temurin", Version: "17.0.9+9", OS: "macos", Arch: "x64", ArchiveType: "tar.gz",
+					URL:    "https://github.com/adoptium/temurin17-binaries/releases/download/jdk-17.0.9%2B9/OpenJDK17U-jdk_x64_mac_hotspot_17.0.9_9.tar.gz",
+					SHA256: "e1b8a0fb0c6fdd0e2b0ba4a4ff5b0e3b2bd6b9e1c9d8bb0dca6f7e1b6d7e4a10"},
+			},
+		},
+		{
+			name:     "zulu",
+			provider: func(baseURL string) Provider { return &zulu{baseURL: baseURL} },
+			os:       "linux", arch: "x64",
+			routes: []route{
+				{path: "/metadata/v1/zulu/packages/", file: "zulu_packages.json", query: map[string]string{"os": "linux", "arch": "x64", "archive_type": "tar.gz", "java_package_type": "jdk"}},
+			},
+			want: []install.Release{
+				{Vendor: "zulu", Version: "21.0.1+12", OS: "linux", Arch: "x64", ArchiveType: "tar.gz",
+					URL:    "https://cdn.azul.com/zulu/bin/zulu21.30.15-ca-jdk21.0.1-linux_x64.tar.gz",
+					SHA256: "0a0b7b3f0f7f0e8e5b4e0b0a6d2f6f6e9a3c3e2b1b0c9d8e7f6a5b4c3d2e1f00"},
+				{Vendor: "zulu", Version: "8.0.392+8", OS: "linux", Arch: "x64", ArchiveType: "tar.gz",
+					URL:    "https://cdn.azul.com/zulu/bin/zulu8.74.0.17-ca-jdk8.0.392-linux_x64.tar.gz",
+					SHA256: "2222222222222222222222222222222222222222222222222222222222222222"},
+			},
+		},
+		{
+			name:     "corretto",
+			provider: func(baseURL string) Provider { return &corretto{indexURL: baseURL + "/index.json"} },
+			os:       "linux", arch: "x64",
+			routes: []route{
+				{path: "/index.json", file: "corretto_index.json"},
+			},
+			want: []install.Release{
+				{Vendor: "corretto", Version: "21.0.1+12", OS: "linux", Arch: "x64", ArchiveType: "tar.gz",
+					URL:    "https://corretto.aws/downloads/resources/21.0.1.12.1/amazon-corretto-21.0.1.12.1-linux-x64.tar.gz",
+					SHA256: "3333333333333333333333333333333333333333333333333333333333333333"},
+				{Vendor: "corretto", Version: "8.0.392+8", OS: "linux", Arch: "x64", ArchiveType: "tar.gz",
+					URL:    "https://corretto.aws/downloads/resources/8.392.08.1/amazon-corretto-8.392.08.1-linux-x64.tar.gz",
+					SHA256: "5555555555555555555555555555555555555555555555555555555555555555"},
+			},
+		},
+		{
+			name:     "liberica",
+			provider: func(baseURL string) Provider { return &liberica{baseURL: baseURL} },
+			os:       "linux", arch: "x64",
+			routes: []route{
+				{path: "/v1/liberica/releases", file: "liberica_releases.json", query: map[string]string{"os": "linux", "arch": "x86", "bitness": "64", "package-type": "tar.gz"}},
+			},
+			want: []install.Release{
+				{Vendor: "liberica", Version: "21.0.1+12", OS: "linux", Arch: "x64", ArchiveType: "tar.gz",
+					URL:  "https://download.bell-sw.com/java/21.0.1+12/bellsoft-jdk21.0.1+12-linux-amd64.tar.gz",
+					SHA1: "8c1b0d3f8e0f5f3e2a9b6c7d8e9f0a1b2c3d4e5f"},
+				{Vendor: "liberica", Version: "8.0.392+9", OS: "linux", Arch: "x64", ArchiveType: "tar.gz",
+					URL:  "https://download.bell-sw.com/java/8u392+9/bellsoft-jdk8u392+9-linux-amd64.tar.gz",
+					SHA1: "9d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c"},
+			},
+		},
+		{
+			name:     "microsoft",
+			provider: func(baseURL string) Provider { return &microsoft{baseURL: baseURL} },
+			os:       "windows", arch: "x64",
+			routes: []route{
+				{path: "/disco/v3.0/packages", file: "foojay_microsoft.json", query: map[string]string{"distribution": "microsoft", "operating_system": "windows", "archive_type": "zip"}},
+			},
+			want: []install.Release{
+				{Vendor: "microsoft", Version: "21.0.1", OS: "windows", Arch: "x64", ArchiveType: "zip",
+					URL:         "https://aka.ms/download-jdk/microsoft-jdk-21.0.1-windows-x64.zip",
+					ChecksumURL: "https://aka.ms/download-jdk/microsoft-jdk-21.0.1-windows-x64.zip.sha256sum.txt"},
+			},
+		},
+		{
+			name:     "graalvm",
+			provider: func(baseURL string) Provider { return &graalvm{baseURL: baseURL} },
+			os:       "linux", arch: "aarch64",
+			routes: []route{
+				{path: "/repos/graalvm/graalvm-ce-builds/releases", file: "github_graalvm_releases.json"},
+			},
+			want: []install.Release{
+				{Vendor: "graalvm-ce", Version: "21.0.1", OS: "linux", Arch: "aarch64", ArchiveType: "tar.gz",
+					URL:         "https://github.com/graalvm/graalvm-ce-builds/releases/download/jdk-21.0.1/graalvm-community-jdk-21.0.1_linux-aarch64_bin.tar.gz",
+					ChecksumURL: "https://github.com/graalvm/graalvm-ce-builds/releases/download/jdk-21.0.1/graalvm-community-jdk-21.0.1_linux-aarch64_bin.tar.gz.sha256"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := serve(t, tt.routes)
+			got, err := tt.provider(srv.URL).Releases(tt.os, tt.arch)
+			if err != nil {
+				t.Fatalf("Releases() error: %v", err)
+			}
+			// Corretto的索引是JSON对象，遍历顺序不固定
+			sortReleases(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Releases() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	for _, p := range []Provider{
+		&temurin{baseURL: srv.URL},
+		&zulu{baseURL: srv.URL},
+		&corretto{indexURL: srv.URL + "/missing.json"},
+		&liberica{baseURL: srv.URL},
+		&microsoft{baseURL: srv.URL},
+		&graalvm{baseURL: srv.URL},
+	} {
+		t.Run(p.Name(), func(t *testing.T) {
+			if _, err := p.Releases("linux", "x64"); err == nil {
+				t.Error("Releases() succeeded on a 404 response, want error")
+			}
+		})
+	}
+}
+
+func TestCorrettoVersion(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"21.0.1.12.1", "21.0.1+12"},
+		{"17.0.9.8.1", "17.0.9+8"},
+		{"11.0.21.9.1", "11.0.21+9"},
+		{"8.392.08.1", "8.0.392+8"},
+		{"8.402.06.1", "8.0.402+6"},
+		{"21.0.1", ""},
+		{"latest", ""},
+	}
+	for _, tt := range tests {
+		if got := correttoVersion(tt.in); got != tt.want {
+			t.Errorf("correttoVersion(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLibericaVersion(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"8u392+9", "8.0.392+9"},
+		{"8u402+7", "8.0.402+7"},
+		{"21.0.1+12", "21.0.1+12"},
+		{"17.0.9+11", "17.0.9+11"},
+	}
+	for _, tt := range tests {
+		if got := libericaVersion(tt.in); got != tt.want {
+			t.Errorf("libericaVersion(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
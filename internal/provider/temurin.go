@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+
+	"javaman/internal/install"
+)
+
+// temurin Eclipse Temurin，使用Adoptium API v3
+type temurin struct {
+	baseURL string
+}
+
+// temurinReleases /v3/info/available_releases的响应
+type temurinReleases struct {
+	AvailableReleases []int `json:"available_releases"`
+}
+
+// temurinAsset /v3/assets/latest的响应条目
+type temurinAsset struct {
+	Binary struct {
+		Package struct {
+			Checksum string `json:"checksum"`
+			Link     string `json:"link"`
+			Name     string `json:"name"`
+		} `json:"package"`
+	} `json:"binary"`
+	Version struct {
+		Semver string `json:"semver"`
+	} `json:"version"`
+}
+
+func (t *temurin) Name() string {
+	return "temurin"
+}
+
+func (t *temurin) Releases(osName, arch string) ([]install.Release, error) {
+	var available temurinReleases
+	if err := getJSON(t.baseURL+"/v3/info/available_releases", &available); err != nil {
+		return nil, err
+	}
+
+	// Adoptium使用mac和x32
+	apiOS := osName
+	if apiOS == "macos" {
+		apiOS = "mac"
+	}
+	apiArch := arch
+	if apiArch == "x86" {
+		apiArch = "x32"
+	}
+
+	var releases []install.Release
+	for _, feature := range available.AvailableReleases {
+		query := url.Values{
+			"architecture": {apiArch},
+			"image_type":   {"jdk"},
+			"os":           {apiOS},
+			"vendor":       {"eclipse"},
+		}
+		var assets []temurinAsset
+		if err := getJSON(fmt.Sprintf("%s/v3/assets/latest/%d/hotspot?%s", t.baseURL, feature, query.Encode()), &assets); err != nil {
+			return nil, err
+		}
+		for _, asset := range assets {
+			pkg := asset.Binary.Package
+			kind := archiveOf(pkg.Name)
+			if kind == "" || asset.Version.Semver == "" {
+				continue
+			}
+			releases = append(releases, install.Release{
+				Vendor:      t.Name(),
+				Version:     asset.Version.Semver,
+				OS:          osName,
+				Arch:        arch,
+				ArchiveType: kind,
+				URL:         pkg.Link,
+				SHA256:      pkg.Checksum,
+			})
+		}
+	}
+	return releases, nil
+}
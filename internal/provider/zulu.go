@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"javaman/internal/install"
+)
+
+// zulu Azul Zulu，使用Azul Metadata API v1
+type zulu struct {
+	baseURL string
+}
+
+// zuluPackage /metadata/v1/zulu/packages的响应条目
+type zuluPackage struct {
+	Name               string `json:"name"`
+	JavaVersion        []int  `json:"java_version"`
+	OpenJDKBuildNumber int    `json:"openjdk_build_number"`
+	DownloadURL        string `json:"download_url"`
+	SHA256Hash         string `json:"sha256_hash"`
+}
+
+func (z *zulu) Name() string {
+	return "zulu"
+}
+
+func (z *zulu) Releases(osName, arch string) ([]install.Release, error) {
+	apiArch := arch
+	if apiArch == "x86" {
+		apiArch = "i686"
+	}
+	archiveType := "tar.gz"
+	if osName == "windows" {
+		archiveType = "zip"
+	}
+
+	query := url.Values{
+		"os":                 {osName},
+		"arch":               {apiArch},
+		"archive_type":       {archiveType},
+		"java_package_type":  {"jdk"},
+		"javafx_bundled":     {"false"},
+		"release_status":     {"ga"},
+		"availability_types": {"CA"},
+		"latest":             {"true"},
+		"include_fields":     {"sha256_hash"},
+		"page":               {"1"},
+		"page_size":          {"1000"},
+	}
+	var packages []zuluPackage
+	if err := getJSON(z.baseURL+"/metadata/v1/zulu/packages/?"+query.Encode(), &packages); err != nil {
+		return nil, err
+	}
+
+	var releases []install.Release
+	for _, pkg := range packages {
+		// linux同时返回musl版本，只保留glibc版本
+		if len(pkg.JavaVersion) == 0 || strings.Contains(pkg.Name, "musl") {
+			continue
+		}
+		parts := make([]string, len(pkg.JavaVersion))
+		for i, n := range pkg.JavaVersion {
+			parts[i] = strconv.Itoa(n)
+		}
+		version := strings.Join(parts, ".")
+		if pkg.OpenJDKBuildNumber > 0 {
+			version += "+" + strconv.Itoa(pkg.OpenJDKBuildNumber)
+		}
+		releases = append(releases, install.Release{
+			Vendor:      z.Name(),
+			Version:     version,
+			OS:          osName,
+			Arch:        arch,
+			ArchiveType: archiveType,
+			URL:         pkg.DownloadURL,
+			SHA256:      pkg.SHA256Hash,
+		})
+	}
+	return releases, nil
+}
@@ -0,0 +1,113 @@
+package resolve
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"javaman/internal/backup"
+)
+
+// ProjectFileName javaman自己的项目级版本文件名
+const ProjectFileName = ".java-version"
+
+// projectFile 描述一种项目版本文件格式
+type projectFile struct {
+	name  string
+	parse func(content string) string
+}
+
+// projectFiles 同一目录中按顺序检查的版本文件
+var projectFiles = []projectFile{
+	{name: ProjectFileName, parse: parseJavaVersion},
+	{name: ".sdkmanrc", parse: parseSdkmanrc},
+	{name: ".tool-versions", parse: parseToolVersions},
+	{name: ".jvmrc", parse: parseJavaVersion},
+}
+
+// FindProjectFile 从dir开始逐级向上查找最近的项目版本文件
+// 未找到时返回空路径
+func FindProjectFile(dir string) (file string, version string, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	for {
+		for _, pf := range projectFiles {
+			file = filepath.Join(dir, pf.name)
+			content, err := os.ReadFile(file)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return "", "", fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			// 文件存在但没有指定Java版本时继续查找
+			if version := pf.parse(string(content)); version != "" {
+				return file, version, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+// WriteProjectFile 在dir中写入项目版本文件，修改前通过session备份
+func WriteProjectFile(session *backup.Session, dir, version string) (string, error) {
+	file := filepath.Join(dir, ProjectFileName)
+	if err := session.WriteFile(file, []byte(version+"\n"), "project JDK version"); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return file, nil
+}
+
+// parseJavaVersion 解析.java-version和.jvmrc，取第一个非空、非注释行
+func parseJavaVersion(content string) string {
+	for _, line := range contentLines(content) {
+		return line
+	}
+	return ""
+}
+
+// parseSdkmanrc 解析.sdkmanrc中的java=17.0.9-tem
+func parseSdkmanrc(content string) string {
+	for _, line := range contentLines(content) {
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(key) == "java" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// parseToolVersions 解析asdf的.tool-versions中的java temurin-17.0.9
+// 同一行有多个版本时取第一个
+func parseToolVersions(content string) string {
+	for _, line := range contentLines(content) {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "java" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// contentLines 返回去除注释和空白后的非空行
+func contentLines(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if idx := strings.Index(line, "#"); idx != -1 {
+			line = line[:idx]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
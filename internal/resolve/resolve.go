@@ -0,0 +1,90 @@
+package resolve
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"javaman/internal/config"
+	"javaman/internal/detect"
+)
+
+// VersionEnvVar 会话级版本覆盖，由shell集成在use时设置
+const VersionEnvVar = "JAVAMAN_VERSION"
+
+// 版本选择规则
+const (
+	SourceEnv      = "env override"
+	SourceProject  = "project file"
+	SourceDefault  = "default"
+	SourceArgument = "command line"
+)
+
+// ErrNoVersion 没有任何规则选中JDK版本
+var ErrNoVersion = errors.New("no JDK version selected. Use 'javaman use <version>' or set a default version with 'javaman default <version>'")
+
+// Selection 表示一次版本解析的结果
+type Selection struct {
+	Version string // 配置中的版本号
+	Path    string // JDK安装目录
+	Source  string // 选中该版本的规则
+	Origin  string // 规则的具体来源，例如环境变量名或文件路径
+}
+
+// Resolve 按优先级解析当前应使用的JDK：
+// 会话环境变量 > 项目版本文件 > 默认版本
+// 最后使用的版本只用于显示，不参与选择，否则一个终端中的use会影响所有新的shell
+func Resolve() (*Selection, error) {
+	if name := os.Getenv(VersionEnvVar); name != "" {
+		return lookup(name, SourceEnv, VersionEnvVar)
+	}
+
+	if dir, err := os.Getwd(); err == nil {
+		file, name, err := FindProjectFile(dir)
+		if err != nil {
+			return nil, err
+		}
+		if file != "" {
+			return lookup(name, SourceProject, file)
+		}
+	}
+
+	cfg := config.GetConfig()
+	if cfg.Settings.Default != "" {
+		return lookup(cfg.Settings.Default, SourceDefault, "settings.default")
+	}
+
+	return nil, ErrNoVersion
+}
+
+// Match 查找与版本标识对应的已配置JDK，所有按版本选择JDK的命令都使用它
+// 除了版本号、版本范围和别名外，还支持.sdkmanrc、.tool-versions等使用的带发行商标识。
+// 完整的版本号只匹配该版本，不会退回到同一主版本的其他JDK；需要任意17.x时应写17或范围
+func Match(name string) (version string, path string, err error) {
+	version, path, err = config.Lookup(name)
+	if err == nil {
+		return version, path, nil
+	}
+
+	// 标识中的发行商写法可能与配置中的不同，例如17.0.9-tem对应temurin-17.0.9
+	if vendor, ver := detect.SplitQualifiedVersion(name); vendor != "" {
+		if v, p, lookupErr := config.Lookup(vendor + "-" + ver); lookupErr == nil {
+			return v, p, nil
+		}
+	}
+	return "", "", err
+}
+
+// lookup 查找版本并构造解析结果
+func lookup(name, source, origin string) (*Selection, error) {
+	version, path, err := Match(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w (selected by %s from %s)", err, source, origin)
+	}
+	return &Selection{
+		Version: version,
+		Path:    path,
+		Source:  source,
+		Origin:  origin,
+	}, nil
+}
@@ -0,0 +1,162 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"javaman/internal/config"
+	"javaman/internal/detect"
+)
+
+// Entry 一条检测结果与配置的差异
+type Entry struct {
+	ID      string
+	Path    string
+	OldPath string // 路径变化时为配置中原来的路径
+	OldInfo string // 元数据变化时为原来的版本描述
+	NewInfo string // 新的版本描述
+}
+
+// Plan 重新检测JDK后与配置的差异
+type Plan struct {
+	New     []Entry // 检测到但尚未配置的JDK
+	Changed []Entry // 路径或版本发生变化的JDK
+	Missing []Entry // 配置中目录已不存在的JDK
+}
+
+// Empty 检查是否没有任何差异
+func (p *Plan) Empty() bool {
+	return len(p.New) == 0 && len(p.Changed) == 0 && len(p.Missing) == 0
+}
+
+// Compute 重新检测JDK并与配置比较
+// 按实际路径（解析符号链接后）匹配，不依赖标识，因此手动添加时指定的标识不受影响
+func Compute() (*Plan, error) {
+	detected, err := detect.DetectJDKs(config.DetectOptions())
+	if err != nil {
+		return nil, err
+	}
+	versions := config.GetVersions()
+
+	configured := make(map[string]string, len(versions))
+	for id, path := range versions {
+		configured[realPath(path)] = id
+	}
+
+	plan := &Plan{}
+	moved := make(map[string]bool)
+	for _, id := range sortedKeys(detected) {
+		path := detected[id]
+		if _, ok := configured[realPath(path)]; ok {
+			continue
+		}
+
+		// 同一标识的JDK原目录已不存在，视为移动到了新位置
+		if oldPath, ok := versions[id]; ok && !exists(oldPath) && !moved[id] {
+			moved[id] = true
+			plan.Changed = append(plan.Changed, Entry{ID: id, Path: path, OldPath: oldPath})
+			continue
+		}
+
+		newID := detect.UniqueID(id, func(candidate string) bool {
+			if _, ok := versions[candidate]; ok {
+				return true
+			}
+			for _, e := range plan.New {
+				if e.ID == candidate {
+					return true
+				}
+			}
+			return false
+		})
+		plan.New = append(plan.New, Entry{ID: newID, Path: path, NewInfo: describe(path)})
+	}
+
+	for _, id := range sortedKeys(versions) {
+		path := versions[id]
+		if moved[id] {
+			continue
+		}
+		if !exists(path) {
+			plan.Missing = append(plan.Missing, Entry{ID: id, Path: path})
+			continue
+		}
+
+		// 同一目录中的JDK被升级，例如通过apt更新
+		old := ""
+		if info, ok := config.GetInfo(id); ok {
+			old = info.Summary()
+		}
+		if current := describe(path); current != "" && current != old {
+			plan.Changed = append(plan.Changed, Entry{ID: id, Path: path, OldInfo: old, NewInfo: current})
+		}
+	}
+	return plan, nil
+}
+
+// Apply 将差异合并到配置，prune为true时删除目录已不存在的JDK
+// 别名保持不变，指向被删除版本的别名需要用户自行修改
+func Apply(plan *Plan, prune bool) error {
+	for _, e := range plan.New {
+		if err := config.AddVersion(e.ID, e.Path); err != nil {
+			return err
+		}
+	}
+	for _, e := range plan.Changed {
+		// 重新添加会更新路径和元数据，标识保持不变
+		if err := config.AddVersion(e.ID, e.Path); err != nil {
+			return err
+		}
+	}
+	if !prune {
+		return nil
+	}
+
+	cfg := config.GetConfig()
+	for _, e := range plan.Missing {
+		if cfg.Settings.Default == e.ID {
+			cfg.Settings.Default = ""
+		}
+		if cfg.Settings.LastUsed == e.ID {
+			cfg.Settings.LastUsed = ""
+		}
+		if err := config.RemoveVersion(e.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// describe 返回JDK的版本描述，无法读取release文件时为空
+func describe(path string) string {
+	info, err := detect.ParseRelease(path)
+	if err != nil {
+		return ""
+	}
+	return info.Summary()
+}
+
+// realPath 解析符号链接后的路径，解析失败时返回清理后的原路径
+func realPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// exists 检查路径是否存在
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// sortedKeys 返回排序后的键
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
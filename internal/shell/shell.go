@@ -0,0 +1,304 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 支持的shell类型
+const (
+	Bash       = "bash"
+	Zsh        = "zsh"
+	Fish       = "fish"
+	Nushell    = "nu"
+	PowerShell = "pwsh"
+)
+
+// SessionEnvVar 由shell包装函数设置，告知javaman应输出哪种shell的脚本
+const SessionEnvVar = "JAVAMAN_SHELL"
+
+// Var 表示一个需要导出的环境变量
+type Var struct {
+	Name  string
+	Value string
+}
+
+// Supported 返回所有支持的shell名称
+func Supported() []string {
+	return []string{Bash, Zsh, Fish, Nushell, PowerShell}
+}
+
+// IsSupported 检查shell是否受支持
+func IsSupported(name string) bool {
+	for _, s := range Supported() {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Detect 根据$SHELL环境变量检测当前shell，无法识别时返回bash
+func Detect() string {
+	name := filepath.Base(os.Getenv("SHELL"))
+	if IsSupported(name) {
+		return name
+	}
+	return Bash
+}
+
+// Exports 生成可被指定shell eval的环境变量导出语句
+func Exports(sh string, vars []Var) (string, error) {
+	var b strings.Builder
+	for _, v := range vars {
+		switch sh {
+		case Bash, Zsh:
+			fmt.Fprintf(&b, "export %s=%s\n", v.Name, quotePosix(v.Value))
+		case Fish:
+			if v.Name == "PATH" {
+				// fish中PATH是列表变量，需要逐项设置
+				parts := filepath.SplitList(v.Value)
+				quoted := make([]string, 0, len(parts))
+				for _, p := range parts {
+					quoted = append(quoted, quoteFish(p))
+				}
+				fmt.Fprintf(&b, "set -gx PATH %s;\n", strings.Join(quoted, " "))
+			} else {
+				fmt.Fprintf(&b, "set -gx %s %s;\n", v.Name, quoteFish(v.Value))
+			}
+		case Nushell:
+			if v.Name == "PATH" {
+				// nushell中PATH是列表
+				parts := filepath.SplitList(v.Value)
+				quoted := make([]string, 0, len(parts))
+				for _, p := range parts {
+					quoted = append(quoted, quoteNu(p))
+				}
+				fmt.Fprintf(&b, "$env.PATH = [%s]\n", strings.Join(quoted, ", "))
+			} else {
+				fmt.Fprintf(&b, "$env.%s = %s\n", v.Name, quoteNu(v.Value))
+			}
+		case PowerShell:
+			fmt.Fprintf(&b, "$env:%s = %s\n", v.Name, quotePowerShell(v.Value))
+		default:
+			return "", fmt.Errorf("unsupported shell: %s", sh)
+		}
+	}
+	return b.String(), nil
+}
+
+// Profile 生成写入shell配置文件的语句：设置JAVA_HOME，并将$JAVA_HOME/bin放在PATH开头
+// PATH引用JAVA_HOME而不是写入固定值，重复加载时不会产生重复项
+func Profile(sh, javaHome string) (string, error) {
+	switch sh {
+	case Bash, Zsh:
+		return "export JAVA_HOME=" + quotePosix(javaHome) + "\n" +
+			`case ":$PATH:" in *":$JAVA_HOME/bin:"*) ;; *) export PATH="$JAVA_HOME/bin:$PATH" ;; esac` + "\n", nil
+	case Fish:
+		return "set -gx JAVA_HOME " + quoteFish(javaHome) + "\n" +
+			"contains -- $JAVA_HOME/bin $PATH; or set -gx PATH $JAVA_HOME/bin $PATH\n", nil
+	case Nushell:
+		return "$env.JAVA_HOME = " + quoteNu(javaHome) + "\n" +
+			"$env.PATH = ($env.PATH | split row (char esep) | where $it != ($env.JAVA_HOME | path join bin) | prepend ($env.JAVA_HOME | path join bin))\n", nil
+	case PowerShell:
+		return "$env:JAVA_HOME = " + quotePowerShell(javaHome) + "\n" +
+			"if (($env:PATH -split [IO.Path]::PathSeparator) -notcontains (Join-Path $env:JAVA_HOME 'bin')) { $env:PATH = (Join-Path $env:JAVA_HOME 'bin') + [IO.Path]::PathSeparator + $env:PATH }\n", nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s", sh)
+	}
+}
+
+// ProfileJavaHome 从Profile生成的内容中读取JAVA_HOME，没有找到时返回false
+func ProfileJavaHome(sh, content string) (string, bool) {
+	var prefix string
+	var unquote func(string) (string, bool)
+	switch sh {
+	case Bash, Zsh:
+		prefix, unquote = "export JAVA_HOME=", unquotePosix
+	case Fish:
+		prefix, unquote = "set -gx JAVA_HOME ", unquoteFish
+	case Nushell:
+		prefix, unquote = "$env.JAVA_HOME = ", unquoteNu
+	case PowerShell:
+		prefix, unquote = "$env:JAVA_HOME = ", unquotePowerShell
+	default:
+		return "", false
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), prefix); ok {
+			return unquote(strings.TrimSpace(value))
+		}
+	}
+	return "", false
+}
+
+// EnvFileName 返回保存Profile输出的文件名，例如env.sh
+func EnvFileName(sh string) (string, error) {
+	switch sh {
+	case Bash, Zsh:
+		return "env.sh", nil
+	case Fish:
+		return "env.fish", nil
+	case Nushell:
+		return "env.nu", nil
+	case PowerShell:
+		return "env.ps1", nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s", sh)
+	}
+}
+
+// SourceLine 生成在shell配置文件中加载file的语句
+func SourceLine(sh, file string) (string, error) {
+	switch sh {
+	case Bash, Zsh:
+		return fmt.Sprintf("[ -f %[1]s ] && . %[1]s", quotePosix(file)), nil
+	case Fish:
+		return fmt.Sprintf("test -f %[1]s; and source %[1]s", quoteFish(file)), nil
+	case Nushell:
+		// nushell在解析时加载文件，文件必须存在
+		return "source " + quoteNu(file), nil
+	case PowerShell:
+		return fmt.Sprintf("if (Test-Path %[1]s) { . %[1]s }", quotePowerShell(file)), nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s", sh)
+	}
+}
+
+// InitScript 生成shell集成脚本，包装javaman命令使use在当前会话生效，并在启动时应用默认版本
+func InitScript(sh string) (string, error) {
+	switch sh {
+	case Bash, Zsh:
+		return fmt.Sprintf(posixInitScript, sh), nil
+	case Fish:
+		return fishInitScript, nil
+	case PowerShell:
+		return powerShellInitScript, nil
+	case Nushell:
+		// nushell无法执行动态生成的脚本
+		return "", fmt.Errorf("shell integration is not available for nushell, use 'javaman use --global' instead")
+	default:
+		return "", fmt.Errorf("unsupported shell: %s", sh)
+	}
+}
+
+// quotePosix 使用单引号转义POSIX shell字符串
+func quotePosix(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// unquotePosix 还原quotePosix转义的字符串
+func unquotePosix(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", false
+	}
+	return strings.ReplaceAll(s[1:len(s)-1], `'\''`, "'"), true
+}
+
+// quoteFish 使用单引号转义fish字符串
+func quoteFish(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "'", `\'`)
+	return "'" + s + "'"
+}
+
+// unquoteFish 还原quoteFish转义的字符串
+func unquoteFish(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", false
+	}
+	var b strings.Builder
+	inner := s[1 : len(s)-1]
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) && (inner[i+1] == '\\' || inner[i+1] == '\'') {
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String(), true
+}
+
+// quoteNu 转义nushell字符串，单引号字符串中不能包含单引号，此时使用原始字符串
+func quoteNu(s string) string {
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	return "r#'" + s + "'#"
+}
+
+// unquoteNu 还原quoteNu转义的字符串
+func unquoteNu(s string) (string, bool) {
+	if inner, ok := strings.CutPrefix(s, "r#'"); ok {
+		inner, ok = strings.CutSuffix(inner, "'#")
+		return inner, ok
+	}
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", false
+	}
+	return s[1 : len(s)-1], true
+}
+
+// quotePowerShell 使用单引号转义PowerShell字符串
+func quotePowerShell(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// unquotePowerShell 还原quotePowerShell转义的字符串
+func unquotePowerShell(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", false
+	}
+	return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), true
+}
+
+const posixInitScript = `javaman() {
+  case "$1" in
+    use)
+      local __javaman_out
+      __javaman_out="$(` + SessionEnvVar + `=%[1]s command javaman "$@")" || return $?
+      eval "$__javaman_out"
+      ;;
+    *)
+      command javaman "$@"
+      ;;
+  esac
+}
+eval "$(command javaman env --shell %[1]s)"
+`
+
+const fishInitScript = `function javaman
+    switch "$argv[1]"
+        case use
+            set -l __javaman_out (env ` + SessionEnvVar + `=fish javaman $argv)
+            or return $status
+            string join \n -- $__javaman_out | source
+        case '*'
+            command javaman $argv
+    end
+end
+command javaman env --shell fish | source
+`
+
+const powerShellInitScript = `function javaman {
+    $exe = Get-Command -CommandType Application javaman | Select-Object -First 1
+    if ($args.Count -gt 0 -and $args[0] -eq 'use') {
+        $env:` + SessionEnvVar + ` = 'pwsh'
+        try {
+            $out = & $exe @args
+        } finally {
+            Remove-Item Env:` + SessionEnvVar + `
+        }
+        if ($LASTEXITCODE -ne 0) { return }
+        Invoke-Expression ($out -join [Environment]::NewLine)
+    } else {
+        & $exe @args
+    }
+}
+$__javaman_env = & (Get-Command -CommandType Application javaman | Select-Object -First 1) env --shell pwsh
+if ($LASTEXITCODE -eq 0 -and $__javaman_env) {
+    Invoke-Expression ($__javaman_env -join [Environment]::NewLine)
+}
+Remove-Variable __javaman_env
+`
@@ -0,0 +1,35 @@
+package shell
+
+import "testing"
+
+func TestProfileJavaHome(t *testing.T) {
+	homes := []string{
+		"/usr/lib/jvm/temurin-17",
+		"/home/o'brien/.javaman/jdks/zulu-21",
+		`C:\Program Files\Java\jdk-17`,
+		`/odd/it's \'quoted\'`,
+	}
+	for _, sh := range Supported() {
+		for _, home := range homes {
+			t.Run(sh+" "+home, func(t *testing.T) {
+				script, err := Profile(sh, home)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, ok := ProfileJavaHome(sh, "# Generated by javaman, do not edit\n"+script)
+				if !ok || got != home {
+					t.Errorf("ProfileJavaHome() = %q, %v, want %q", got, ok, home)
+				}
+			})
+		}
+	}
+}
+
+func TestProfileJavaHomeMissing(t *testing.T) {
+	if _, ok := ProfileJavaHome(Bash, "# empty\n"); ok {
+		t.Error("ProfileJavaHome() found JAVA_HOME in a file without it")
+	}
+	if _, ok := ProfileJavaHome("tcsh", "setenv JAVA_HOME /x\n"); ok {
+		t.Error("ProfileJavaHome() accepted an unsupported shell")
+	}
+}
@@ -0,0 +1,163 @@
+package shim
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"javaman/internal/config"
+)
+
+const shimDirName = "shims"
+
+// Dir 获取shim目录（~/.javaman/shims）
+func Dir() (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, shimDirName), nil
+}
+
+// Exists 检查shim目录是否已经创建
+func Exists() bool {
+	dir, err := Dir()
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// OnPath 检查shim目录是否在PATH中
+func OnPath() bool {
+	dir, err := Dir()
+	if err != nil {
+		return false
+	}
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = real
+	}
+	for _, p := range filepath.SplitList(os.Getenv("PATH")) {
+		if p == "" {
+			continue
+		}
+		if real, err := filepath.EvalSymlinks(p); err == nil {
+			p = real
+		}
+		if filepath.Clean(p) == filepath.Clean(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tools 收集所有JDK的bin目录中的可执行文件名
+func Tools(jdkPaths []string) []string {
+	seen := make(map[string]bool)
+	for _, jdkPath := range jdkPaths {
+		entries, err := os.ReadDir(filepath.Join(jdkPath, "bin"))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if name, ok := toolName(filepath.Join(jdkPath, "bin"), entry); ok {
+				seen[name] = true
+			}
+		}
+	}
+
+	tools := make([]string, 0, len(seen))
+	for name := range seen {
+		tools = append(tools, name)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// Sync 根据JDK列表重新生成shim，并删除不再存在的工具对应的shim
+func Sync(jdkPaths []string) ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create shim directory: %w", err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate javaman executable: %w", err)
+	}
+
+	tools := Tools(jdkPaths)
+	wanted := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		file := filepath.Join(dir, shimFileName(tool))
+		wanted[filepath.Base(file)] = true
+		if err := os.WriteFile(file, []byte(shimScript(exe, tool)), 0755); err != nil {
+			return nil, fmt.Errorf("failed to write shim %s: %w", file, err)
+		}
+	}
+
+	// 删除过期的shim
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shim directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !wanted[entry.Name()] {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				return nil, fmt.Errorf("failed to remove stale shim %s: %w", entry.Name(), err)
+			}
+		}
+	}
+
+	return tools, nil
+}
+
+// ToolPath 返回JDK中指定工具的可执行文件路径
+func ToolPath(jdkPath, tool string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(jdkPath, "bin", tool+".exe")
+	}
+	return filepath.Join(jdkPath, "bin", tool)
+}
+
+// toolName 判断目录项是否为可执行工具，并返回不带扩展名的工具名
+func toolName(binDir string, entry os.DirEntry) (string, bool) {
+	name := entry.Name()
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(name), ".exe") {
+			return "", false
+		}
+		return strings.TrimSuffix(name, filepath.Ext(name)), true
+	}
+
+	// 跟随符号链接判断是否为可执行的普通文件
+	info, err := os.Stat(filepath.Join(binDir, name))
+	if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return "", false
+	}
+	return name, true
+}
+
+// shimFileName 返回工具对应的shim文件名
+func shimFileName(tool string) string {
+	if runtime.GOOS == "windows" {
+		return tool + ".cmd"
+	}
+	return tool
+}
+
+// shimScript 生成调用javaman分发到具体JDK的shim脚本
+func shimScript(exe, tool string) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("@echo off\r\n\"%s\" shim-exec %s %%*\r\n", exe, tool)
+	}
+	return fmt.Sprintf("#!/bin/sh\n# javaman shim for %s\nexec '%s' shim-exec '%s' \"$@\"\n",
+		tool, strings.ReplaceAll(exe, "'", `'\''`), tool)
+}
@@ -0,0 +1,270 @@
+package toolchains
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"javaman/internal/backup"
+)
+
+// GradlePathsProperty Gradle读取本地JDK安装路径的属性名
+const GradlePathsProperty = "org.gradle.java.installations.paths"
+
+// GradleFile 返回Gradle用户级gradle.properties路径
+// 优先使用GRADLE_USER_HOME，默认为~/.gradle/gradle.properties
+func GradleFile() (string, error) {
+	if gradleHome := os.Getenv("GRADLE_USER_HOME"); gradleHome != "" {
+		return filepath.Join(gradleHome, "gradle.properties"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".gradle", "gradle.properties"), nil
+}
+
+// gradleManagedComment 写在安装路径属性前的标记注释，表示该属性由javaman同步
+// 没有javaman写入的路径时也保留，使add、remove等命令之后仍然继续同步
+const gradleManagedComment = "# javaman managed paths:"
+
+// gradleManagedPathComment 在标记注释之后逐行记录javaman写入的路径
+// 同步时只替换这些路径，用户自己添加的路径保持不变；路径可能包含逗号，所以每行只记录一个
+const gradleManagedPathComment = "# javaman managed path: "
+
+// HasGradlePaths 检查gradle.properties中是否有javaman的标记注释
+func HasGradlePaths(file string) bool {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), gradleManagedComment) {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncGradle 将JDK路径写入gradle.properties的安装路径属性，修改前通过session备份，其他属性保持不变
+// 属性中不是由javaman写入的路径会保留，javaman之前写入但已不存在的JDK会被删除
+func SyncGradle(session *backup.Session, file string, jdks []JDK) (*Changes, error) {
+	content, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	var lines []string
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+
+	// 读取旧的属性值和javaman记录的路径，同时去掉这些行，稍后在第一处定义的位置重新写入
+	var oldValues, ownedPaths []string
+	newLines := make([]string, 0, len(lines)+len(jdks)+2)
+	insertAt := -1
+	for i := 0; i < len(lines); i++ {
+		if paths, ok := gradleManagedPaths(lines[i]); ok {
+			ownedPaths = append(ownedPaths, paths...)
+			continue
+		}
+		if !isGradlePathsLine(lines[i]) {
+			newLines = append(newLines, lines[i])
+			// 其他属性的续行原样保留，即使内容看起来像安装路径属性
+			for !isPropertyComment(lines[i]) && gradleContinues(strings.TrimRight(lines[i], "\r")) && i+1 < len(lines) {
+				i++
+				newLines = append(newLines, lines[i])
+			}
+			continue
+		}
+
+		// 合并续行，续行开头的空白不属于属性值
+		value := strings.TrimRight(lines[i], "\r")
+		for gradleContinues(value) && i+1 < len(lines) {
+			i++
+			value = value[:len(value)-1] + strings.TrimLeft(strings.TrimRight(lines[i], "\r"), " \t\f")
+		}
+		oldValues = append(oldValues, gradlePropertyValue(value))
+
+		// 只保留第一处定义
+		if insertAt == -1 {
+			insertAt = len(newLines)
+		}
+	}
+	if insertAt == -1 {
+		insertAt = len(newLines)
+	}
+
+	owned := make(map[string]bool, len(ownedPaths))
+	for _, p := range ownedPaths {
+		owned[p] = true
+	}
+
+	// 先保留用户的路径，再追加javaman管理的路径
+	var paths []string
+	seen := make(map[string]bool)
+	for _, value := range oldValues {
+		for _, p := range userGradlePaths(value, owned) {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	managed := make([]string, 0, len(jdks))
+	for _, jdk := range jdks {
+		// 注释每行记录一个路径，包含换行的路径无法记录
+		if !seen[jdk.Path] && !strings.ContainsAny(jdk.Path, "\r\n") {
+			seen[jdk.Path] = true
+			managed = append(managed, jdk.Path)
+		}
+	}
+	sort.Strings(managed)
+	paths = append(paths, managed...)
+
+	escaped := make([]string, 0, len(paths))
+	for _, p := range paths {
+		escaped = append(escaped, strings.ReplaceAll(p, `\`, `\\`))
+	}
+	property := []string{gradleManagedComment}
+	for _, p := range managed {
+		property = append(property, gradleManagedPathComment+p)
+	}
+	property = append(property, GradlePathsProperty+"="+strings.Join(escaped, ","))
+	newLines = append(newLines[:insertAt], append(property, newLines[insertAt:]...)...)
+
+	if err := session.WriteFile(file, []byte(strings.Join(newLines, "\n")+"\n"), "javaman installation paths"); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", file, err)
+	}
+
+	return diffPaths(ownedPaths, managed), nil
+}
+
+// gradleManagedPaths 判断一行是否是javaman的注释，并返回其中记录的路径
+// 旧版本在标记注释中用逗号分隔记录全部路径，这种写法仍按逗号拆分读取
+func gradleManagedPaths(line string) (paths []string, ok bool) {
+	line = strings.TrimSpace(line)
+	if p, found := strings.CutPrefix(line, strings.TrimSpace(gradleManagedPathComment)); found {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+		return paths, true
+	}
+	if legacy, found := strings.CutPrefix(line, gradleManagedComment); found {
+		return splitGradlePaths(legacy), true
+	}
+	return nil, false
+}
+
+// isPropertyComment 判断一行是否是properties文件的注释，注释没有续行
+func isPropertyComment(line string) bool {
+	line = strings.TrimLeft(line, " \t\f")
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!")
+}
+
+// gradleContinues 判断属性行是否延续到下一行
+// 与java.util.Properties相同，行尾有奇数个反斜杠时才是续行，偶数个是转义的反斜杠
+func gradleContinues(line string) bool {
+	n := len(line) - len(strings.TrimRight(line, `\`))
+	return n%2 == 1
+}
+
+// isGradlePathsLine 判断一行是否定义了安装路径属性
+func isGradlePathsLine(line string) bool {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, GradlePathsProperty) {
+		return false
+	}
+	rest := strings.TrimSpace(strings.TrimPrefix(line, GradlePathsProperty))
+	return strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ":")
+}
+
+// gradlePropertyValue 返回合并续行后的属性行中反转义的属性值
+func gradlePropertyValue(line string) string {
+	rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), GradlePathsProperty))
+	return unescapeProperty(rest[1:])
+}
+
+// unescapeProperty 按java.util.Properties的规则处理反斜杠转义
+func unescapeProperty(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// userGradlePaths 返回属性值中不是由javaman写入的路径
+// 属性按逗号分隔，包含逗号的路径会被拆成几段，因此将相邻的几段合起来与javaman的记录比较
+func userGradlePaths(value string, owned map[string]bool) []string {
+	parts := strings.Split(value, ",")
+	var paths []string
+	for i := 0; i < len(parts); i++ {
+		matched := false
+		for j := len(parts); j > i+1; j-- {
+			if owned[strings.TrimSpace(strings.Join(parts[i:j], ","))] {
+				i, matched = j-1, true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if p := strings.TrimSpace(parts[i]); p != "" && !owned[p] {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// splitGradlePaths 拆分逗号分隔的路径列表
+func splitGradlePaths(value string) []string {
+	var paths []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// diffPaths 比较新旧路径列表
+func diffPaths(oldPaths, newPaths []string) *Changes {
+	changes := &Changes{}
+	old := make(map[string]bool, len(oldPaths))
+	for _, p := range oldPaths {
+		old[p] = true
+	}
+	current := make(map[string]bool, len(newPaths))
+	for _, p := range newPaths {
+		current[p] = true
+		if !old[p] {
+			changes.Added = append(changes.Added, p)
+		}
+	}
+	for _, p := range oldPaths {
+		if !current[p] {
+			changes.Removed = append(changes.Removed, p)
+		}
+	}
+	return changes
+}
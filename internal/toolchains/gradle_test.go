@@ -0,0 +1,147 @@
+package toolchains
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"javaman/internal/backup"
+)
+
+// tempHome 将用户主目录指向临时目录，使备份写入~/.javaman/backups时不影响真实环境
+func tempHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // Windows
+	return home
+}
+
+func TestSyncGradle(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string // 原有内容，空表示文件不存在
+		jdks        []string
+		want        string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name: "new file",
+			jdks: []string{"/jdks/zulu-17", "/jdks/temurin-21"},
+			want: "# javaman managed paths:\n" +
+				"# javaman managed path: /jdks/temurin-21\n" +
+				"# javaman managed path: /jdks/zulu-17\n" +
+				"org.gradle.java.installations.paths=/jdks/temurin-21,/jdks/zulu-17\n",
+			wantAdded: []string{"/jdks/temurin-21", "/jdks/zulu-17"},
+		},
+		{
+			name: "keeps user paths and other properties",
+			content: "org.gradle.daemon=true\n" +
+				"# javaman managed paths:\n" +
+				"# javaman managed path: /jdks/temurin-17\n" +
+				"org.gradle.java.installations.paths=/opt/custom,/jdks/temurin-17\n" +
+				"org.gradle.parallel=true\n",
+			jdks: []string{"/jdks/temurin-21"},
+			want: "org.gradle.daemon=true\n" +
+				"# javaman managed paths:\n" +
+				"# javaman managed path: /jdks/temurin-21\n" +
+				"org.gradle.java.installations.paths=/opt/custom,/jdks/temurin-21\n" +
+				"org.gradle.parallel=true\n",
+			wantAdded:   []string{"/jdks/temurin-21"},
+			wantRemoved: []string{"/jdks/temurin-17"},
+		},
+		{
+			name: "legacy comma separated comment",
+			content: "# javaman managed paths: /jdks/a,/jdks/b\n" +
+				"org.gradle.java.installations.paths=/opt/custom,/jdks/a,/jdks/b\n",
+			jdks: []string{"/jdks/a"},
+			want: "# javaman managed paths:\n" +
+				"# javaman managed path: /jdks/a\n" +
+				"org.gradle.java.installations.paths=/opt/custom,/jdks/a\n",
+			wantRemoved: []string{"/jdks/b"},
+		},
+		{
+			name: "path containing a comma",
+			content: "# javaman managed paths:\n" +
+				"# javaman managed path: /opt/jdk,17\n" +
+				"org.gradle.java.installations.paths=/opt/custom,/opt/jdk,17\n",
+			want: "# javaman managed paths:\n" +
+				"org.gradle.java.installations.paths=/opt/custom\n",
+			wantRemoved: []string{"/opt/jdk,17"},
+		},
+		{
+			name:    "marker kept without managed paths",
+			content: "# javaman managed paths:\norg.gradle.java.installations.paths=\n",
+			want:    "# javaman managed paths:\norg.gradle.java.installations.paths=\n",
+		},
+		{
+			name: "escaped trailing backslash is not a continuation",
+			content: `org.gradle.java.installations.paths=C:\\jdks\\` + "\n" +
+				"org.gradle.daemon=true\n",
+			want: "# javaman managed paths:\n" +
+				`org.gradle.java.installations.paths=C:\\jdks\\` + "\n" +
+				"org.gradle.daemon=true\n",
+		},
+		{
+			name: "continuation lines",
+			content: "org.gradle.java.installations.paths=/opt/a,\\\n" +
+				"    /opt/b\n" +
+				"org.gradle.daemon=true\n",
+			jdks: []string{"/jdks/c"},
+			want: "# javaman managed paths:\n" +
+				"# javaman managed path: /jdks/c\n" +
+				"org.gradle.java.installations.paths=/opt/a,/opt/b,/jdks/c\n" +
+				"org.gradle.daemon=true\n",
+			wantAdded: []string{"/jdks/c"},
+		},
+		{
+			name: "continuation of another property",
+			content: "org.gradle.jvmargs=-Xmx1g \\\n" +
+				"org.gradle.java.installations.paths=/not/a/key\n",
+			want: "org.gradle.jvmargs=-Xmx1g \\\n" +
+				"org.gradle.java.installations.paths=/not/a/key\n" +
+				"# javaman managed paths:\n" +
+				"org.gradle.java.installations.paths=\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempHome(t)
+			file := filepath.Join(t.TempDir(), "gradle.properties")
+			if tt.content != "" {
+				if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var jdks []JDK
+			for _, p := range tt.jdks {
+				jdks = append(jdks, JDK{ID: filepath.Base(p), Path: p})
+			}
+
+			session := backup.New("test")
+			changes, err := SyncGradle(session, file, jdks)
+			if err != nil {
+				t.Fatalf("SyncGradle() error: %v", err)
+			}
+			if err := session.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("gradle.properties =\n%s\nwant\n%s", got, tt.want)
+			}
+			if !reflect.DeepEqual(changes.Added, tt.wantAdded) || !reflect.DeepEqual(changes.Removed, tt.wantRemoved) {
+				t.Errorf("SyncGradle() changes = +%v -%v, want +%v -%v", changes.Added, changes.Removed, tt.wantAdded, tt.wantRemoved)
+			}
+			if !HasGradlePaths(file) {
+				t.Error("HasGradlePaths() = false after sync")
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package toolchains
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"javaman/internal/backup"
+)
+
+// mavenIDPrefix javaman生成的toolchain在provides/id中使用的前缀，用于识别归属
+const mavenIDPrefix = "javaman:"
+
+// mavenManagedComment 写在javaman条目前的注释内容，表示该文件由javaman同步
+// 删除所有JDK后条目为空，仍保留该注释，使add、remove等命令之后继续同步
+const mavenManagedComment = "javaman managed"
+
+// JDK 描述一个需要注册到构建工具的JDK
+type JDK struct {
+	ID      string // javaman中的版本号
+	Version string // 完整版本号
+	Vendor  string // 发行商，可能为空
+	Path    string // 安装目录
+}
+
+// Changes 记录同步时新增和删除的条目
+type Changes struct {
+	Added   []string
+	Removed []string
+}
+
+// mavenToolchain toolchain元素中用于判断归属的内容
+type mavenToolchain struct {
+	Type     string `xml:"type"`
+	Provides struct {
+		ID string `xml:"id"`
+	} `xml:"provides"`
+}
+
+// mavenElement toolchain元素及其在文件中的字节范围[Start, End)
+type mavenElement struct {
+	mavenToolchain
+	Start, End int
+}
+
+// mavenDocument 解析后的toolchains.xml，只记录修改时需要的位置，其余内容按原始字节保留
+type mavenDocument struct {
+	content    []byte
+	root       string         // 根元素的原始名称，可能带前缀
+	rootEnd    int            // 根元素结束标签的位置
+	selfClosed bool           // 根元素没有子元素，例如<toolchains/>
+	elements   []mavenElement // 根元素下的toolchain元素
+	markers    [][2]int       // 根元素下javaman标记注释的字节范围
+}
+
+// MavenFile 返回默认的Maven toolchains.xml路径（~/.m2/toolchains.xml）
+func MavenFile() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".m2", "toolchains.xml"), nil
+}
+
+// SyncMaven 将JDK写入toolchains.xml，修改前通过session备份
+// 只替换javaman生成的toolchain元素，文件中的注释、其他条目和根元素的属性保持原样
+func SyncMaven(session *backup.Session, file string, jdks []JDK) (*Changes, error) {
+	doc, err := readMavenToolchains(file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(jdks))
+	for _, jdk := range jdks {
+		wanted[mavenIDPrefix+jdk.ID] = true
+	}
+
+	changes := &Changes{}
+	owned := make(map[string]bool)
+	var ranges [][2]int
+	if doc != nil {
+		// 旧的标记注释与条目一起删除，在第一处位置重新写入
+		for _, m := range doc.markers {
+			ranges = append(ranges, lineRange(doc.content, m[0], m[1]))
+		}
+		for _, el := range doc.elements {
+			if !isMavenOwned(el.mavenToolchain) {
+				continue
+			}
+			id := strings.TrimSpace(el.Provides.ID)
+			owned[id] = true
+			if !wanted[id] {
+				changes.Removed = append(changes.Removed, strings.TrimPrefix(id, mavenIDPrefix))
+			}
+			ranges = append(ranges, lineRange(doc.content, el.Start, el.End))
+		}
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	}
+
+	sorted := append([]JDK(nil), jdks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var entries bytes.Buffer
+	entries.WriteString("  <!-- " + mavenManagedComment + " -->\n")
+	for _, jdk := range sorted {
+		if !owned[mavenIDPrefix+jdk.ID] {
+			changes.Added = append(changes.Added, jdk.ID)
+		}
+		writeMavenToolchain(&entries, jdk)
+	}
+
+	var content []byte
+	if doc == nil {
+		content = newMavenToolchains(entries.Bytes())
+	} else {
+		content = doc.splice(ranges, entries.Bytes())
+	}
+
+	if err := session.WriteFile(file, content, "javaman toolchain entries"); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return changes, nil
+}
+
+// HasMavenToolchains 检查toolchains.xml是否由javaman同步，即有javaman的标记注释或生成的条目
+func HasMavenToolchains(file string) bool {
+	doc, err := readMavenToolchains(file)
+	if err != nil {
+		return false
+	}
+	if len(doc.markers) > 0 {
+		return true
+	}
+	for _, el := range doc.elements {
+		if isMavenOwned(el.mavenToolchain) {
+			return true
+		}
+	}
+	return false
+}
+
+// readMavenToolchains 读取toolchains.xml，记录根元素下每个toolchain元素的位置
+func readMavenToolchains(file string) (*mavenDocument, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &mavenDocument{content: content, rootEnd: -1}
+	dec := xml.NewDecoder(bytes.NewReader(content))
+	depth := 0
+	for {
+		// Token返回前的位置就是下一个标记的起始位置
+		offset := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 1 {
+				if t.Name.Local != "toolchains" {
+					return nil, fmt.Errorf("failed to parse %s: root element is <%s>, expected <toolchains>", file, t.Name.Local)
+				}
+				doc.root = rawElementName(content[offset:])
+				// 自闭合的元素没有结束标签，解码器会紧接着返回EndElement
+				doc.selfClosed = bytes.HasSuffix(content[:dec.InputOffset()], []byte("/>"))
+			}
+			if depth == 2 && t.Name.Local == "toolchain" {
+				var tc mavenToolchain
+				if err := dec.DecodeElement(&tc, &t); err != nil {
+					return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+				}
+				depth--
+				doc.elements = append(doc.elements, mavenElement{mavenToolchain: tc, Start: offset, End: int(dec.InputOffset())})
+			}
+		case xml.Comment:
+			if depth == 1 && strings.TrimSpace(string(t)) == mavenManagedComment {
+				doc.markers = append(doc.markers, [2]int{offset, int(dec.InputOffset())})
+			}
+		case xml.EndElement:
+			if depth == 1 {
+				doc.rootEnd = offset
+			}
+			depth--
+		}
+	}
+	if doc.rootEnd == -1 {
+		return nil, fmt.Errorf("failed to parse %s: missing <toolchains> element", file)
+	}
+	return doc, nil
+}
+
+// splice 删除ranges中的元素，并把entries插入到第一个被删除元素的位置，
+// 没有可替换的元素时插入到根元素的结束标签之前
+func (d *mavenDocument) splice(ranges [][2]int, entries []byte) []byte {
+	var b bytes.Buffer
+	if d.selfClosed {
+		// <toolchains/>改写为成对的标签
+		openEnd := d.rootEnd - len("/>")
+		b.Write(d.content[:openEnd])
+		b.WriteString(">\n")
+		b.Write(entries)
+		b.WriteString("</" + d.root + ">")
+		b.Write(d.content[d.rootEnd:])
+		return b.Bytes()
+	}
+
+	insertAt := d.rootEnd
+	if len(ranges) > 0 {
+		insertAt = ranges[0][0]
+	} else if lineStart, ok := indentStart(d.content, d.rootEnd); ok {
+		// 结束标签前只有缩进时插入到该行开头
+		insertAt = lineStart
+	} else {
+		entries = append([]byte("\n"), entries...)
+	}
+
+	pos := 0
+	inserted := false
+	for _, r := range ranges {
+		if r[0] == insertAt && !inserted {
+			b.Write(d.content[pos:r[0]])
+			b.Write(entries)
+			inserted = true
+			pos = r[1]
+			continue
+		}
+		b.Write(d.content[pos:r[0]])
+		pos = r[1]
+	}
+	if !inserted {
+		b.Write(d.content[pos:insertAt])
+		b.Write(entries)
+		pos = insertAt
+	}
+	b.Write(d.content[pos:])
+	return b.Bytes()
+}
+
+// lineRange 扩展[start, end)：元素单独占据整行时包含行首缩进和行尾换行，删除后不留空行
+func lineRange(content []byte, start, end int) [2]int {
+	lineStart, ok := indentStart(content, start)
+	if !ok {
+		return [2]int{start, end}
+	}
+
+	lineEnd := end
+	for lineEnd < len(content) && (content[lineEnd] == ' ' || content[lineEnd] == '\t' || content[lineEnd] == '\r') {
+		lineEnd++
+	}
+	if lineEnd < len(content) && content[lineEnd] != '\n' {
+		return [2]int{start, end}
+	}
+	if lineEnd < len(content) {
+		lineEnd++
+	}
+	return [2]int{lineStart, lineEnd}
+}
+
+// indentStart 返回pos所在行的行首位置，pos之前有缩进以外的内容时返回false
+func indentStart(content []byte, pos int) (int, bool) {
+	for pos > 0 && (content[pos-1] == ' ' || content[pos-1] == '\t') {
+		pos--
+	}
+	return pos, pos == 0 || content[pos-1] == '\n'
+}
+
+// rawElementName 返回开始标签中的原始元素名，例如t:toolchains
+func rawElementName(tag []byte) string {
+	name := bytes.TrimPrefix(tag, []byte("<"))
+	if end := bytes.IndexAny(name, " \t\r\n/>"); end != -1 {
+		name = name[:end]
+	}
+	return string(name)
+}
+
+// newMavenToolchains 生成新的toolchains.xml
+func newMavenToolchains(entries []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<toolchains xmlns="http://maven.apache.org/TOOLCHAINS/1.1.0"` + "\n")
+	b.WriteString(`            xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"` + "\n")
+	b.WriteString(`            xsi:schemaLocation="http://maven.apache.org/TOOLCHAINS/1.1.0 https://maven.apache.org/xsd/toolchains-1.1.0.xsd">` + "\n")
+	b.Write(entries)
+	b.WriteString("</toolchains>\n")
+	return b.Bytes()
+}
+
+// isMavenOwned 判断toolchain是否由javaman生成
+func isMavenOwned(tc mavenToolchain) bool {
+	return strings.TrimSpace(tc.Type) == "jdk" && strings.HasPrefix(strings.TrimSpace(tc.Provides.ID), mavenIDPrefix)
+}
+
+// writeMavenToolchain 输出一个JDK的toolchain元素
+func writeMavenToolchain(b *bytes.Buffer, jdk JDK) {
+	version := jdk.Version
+	if version == "" {
+		version = jdk.ID
+	}
+
+	b.WriteString("  <toolchain>\n")
+	b.WriteString("    <type>jdk</type>\n")
+	b.WriteString("    <provides>\n")
+	writeElement(b, 6, "version", version)
+	if jdk.Vendor != "" {
+		writeElement(b, 6, "vendor", jdk.Vendor)
+	}
+	writeElement(b, 6, "id", mavenIDPrefix+jdk.ID)
+	b.WriteString("    </provides>\n")
+	b.WriteString("    <configuration>\n")
+	writeElement(b, 6, "jdkHome", jdk.Path)
+	b.WriteString("    </configuration>\n")
+	b.WriteString("  </toolchain>\n")
+}
+
+// writeElement 输出一个转义后的文本元素
+func writeElement(b *bytes.Buffer, indent int, name, value string) {
+	b.WriteString(strings.Repeat(" ", indent))
+	fmt.Fprintf(b, "<%s>", name)
+	xml.EscapeText(b, []byte(value))
+	fmt.Fprintf(b, "</%s>\n", name)
+}
@@ -0,0 +1,143 @@
+package toolchains
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"javaman/internal/backup"
+)
+
+const userToolchain = `  <toolchain>
+    <type>jdk</type>
+    <provides>
+      <version>11</version>
+      <id>corp-11</id>
+    </provides>
+    <configuration>
+      <jdkHome>/opt/jdk11</jdkHome>
+    </configuration>
+  </toolchain>
+`
+
+const temurin17Toolchain = `  <toolchain>
+    <type>jdk</type>
+    <provides>
+      <version>17.0.9</version>
+      <vendor>temurin</vendor>
+      <id>javaman:temurin-17.0.9</id>
+    </provides>
+    <configuration>
+      <jdkHome>/jdks/temurin-17.0.9</jdkHome>
+    </configuration>
+  </toolchain>
+`
+
+var temurin17 = JDK{ID: "temurin-17.0.9", Version: "17.0.9", Vendor: "temurin", Path: "/jdks/temurin-17.0.9"}
+
+// syncMaven 同步一次toolchains.xml并返回写入后的内容
+func syncMaven(t *testing.T, file string, jdks []JDK) (string, *Changes) {
+	t.Helper()
+	session := backup.New("test")
+	changes, err := SyncMaven(session, file, jdks)
+	if err != nil {
+		t.Fatalf("SyncMaven() error: %v", err)
+	}
+	if err := session.Close(); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content), changes
+}
+
+func TestSyncMaven(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string // 原有内容，空表示文件不存在
+		jdks        []JDK
+		want        string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name:      "new file",
+			jdks:      []JDK{temurin17},
+			want:      string(newMavenToolchains([]byte("  <!-- javaman managed -->\n" + temurin17Toolchain))),
+			wantAdded: []string{"temurin-17.0.9"},
+		},
+		{
+			name:    "adds marker to a file without one",
+			content: "<toolchains>\n" + userToolchain + temurin17Toolchain + "</toolchains>\n",
+			jdks:    []JDK{temurin17},
+			want:    "<toolchains>\n" + userToolchain + "  <!-- javaman managed -->\n" + temurin17Toolchain + "</toolchains>\n",
+		},
+		{
+			name:        "keeps the marker after the last entry is removed",
+			content:     "<toolchains>\n  <!-- user comment -->\n" + userToolchain + "  <!-- javaman managed -->\n" + temurin17Toolchain + "</toolchains>\n",
+			want:        "<toolchains>\n  <!-- user comment -->\n" + userToolchain + "  <!-- javaman managed -->\n</toolchains>\n",
+			wantRemoved: []string{"temurin-17.0.9"},
+		},
+		{
+			name:      "adds entries after the marker",
+			content:   "<toolchains>\n" + userToolchain + "  <!-- javaman managed -->\n</toolchains>\n",
+			jdks:      []JDK{temurin17},
+			want:      "<toolchains>\n" + userToolchain + "  <!-- javaman managed -->\n" + temurin17Toolchain + "</toolchains>\n",
+			wantAdded: []string{"temurin-17.0.9"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempHome(t)
+			file := filepath.Join(t.TempDir(), "toolchains.xml")
+			if tt.content != "" {
+				if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, changes := syncMaven(t, file, tt.jdks)
+			if got != tt.want {
+				t.Errorf("toolchains.xml =\n%s\nwant\n%s", got, tt.want)
+			}
+			if !reflect.DeepEqual(changes.Added, tt.wantAdded) || !reflect.DeepEqual(changes.Removed, tt.wantRemoved) {
+				t.Errorf("SyncMaven() changes = +%v -%v, want +%v -%v", changes.Added, changes.Removed, tt.wantAdded, tt.wantRemoved)
+			}
+			if !HasMavenToolchains(file) {
+				t.Error("HasMavenToolchains() = false after sync")
+			}
+
+			// 再次同步不应重复写入标记注释
+			if again, _ := syncMaven(t, file, tt.jdks); again != got {
+				t.Errorf("second sync changed toolchains.xml to\n%s", again)
+			}
+		})
+	}
+}
+
+func TestHasMavenToolchains(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"user entries only", "<toolchains>\n" + userToolchain + "</toolchains>\n", false},
+		{"javaman entry without marker", "<toolchains>\n" + temurin17Toolchain + "</toolchains>\n", true},
+		{"marker only", "<toolchains>\n  <!-- javaman managed -->\n</toolchains>\n", true},
+		{"marker nested in an entry", "<toolchains>\n  <toolchain><!-- javaman managed --></toolchain>\n</toolchains>\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "toolchains.xml")
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := HasMavenToolchains(file); got != tt.want {
+				t.Errorf("HasMavenToolchains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
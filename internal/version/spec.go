@@ -0,0 +1,176 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// constraint 单个版本约束
+type constraint struct {
+	op      string // =、>=、>、<=、<，或prefix表示前缀匹配
+	version Version
+}
+
+// Spec 版本范围，多个约束之间为"与"关系
+// 支持的写法：17（前缀）、17+（不低于）、>=11 <21（区间）、~17.0（波浪号范围）、^17（插入号范围）
+type Spec struct {
+	Raw         string
+	constraints []constraint
+}
+
+// ParseSpec 解析版本范围
+func ParseSpec(s string) (*Spec, error) {
+	spec := &Spec{Raw: s}
+	tokens := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+
+	// 允许运算符与版本号之间有空格，例如">= 11"
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if isOperator(token) && i+1 < len(tokens) {
+			token += tokens[i+1]
+			i++
+		}
+
+		constraints, err := parseConstraint(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version spec %q: %w", s, err)
+		}
+		spec.constraints = append(spec.constraints, constraints...)
+	}
+	return spec, nil
+}
+
+// Matches 检查版本是否满足所有约束
+func (s *Spec) Matches(v Version) bool {
+	for _, c := range s.constraints {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesString 检查版本字符串是否满足范围，无法解析的版本不匹配
+func (s *Spec) MatchesString(v string) bool {
+	parsed, err := Parse(v)
+	if err != nil {
+		return false
+	}
+	return s.Matches(parsed)
+}
+
+// parseConstraint 解析单个约束
+func parseConstraint(token string) ([]constraint, error) {
+	if token == "*" || strings.EqualFold(token, "latest") {
+		return nil, nil
+	}
+
+	for _, op := range []string{">=", "<=", "==", ">", "<", "="} {
+		if strings.HasPrefix(token, op) {
+			v, err := Parse(token[len(op):])
+			if err != nil {
+				return nil, err
+			}
+			if op == "==" {
+				op = "="
+			}
+			// 与~、^的上限一致，<21不包含21-ea
+			if op == "<" && v.Pre == "" && v.Build == 0 {
+				v.Pre = "0"
+			}
+			return []constraint{{op: op, version: v}}, nil
+		}
+	}
+
+	// 17+ 表示不低于17
+	if strings.HasSuffix(token, "+") {
+		v, err := Parse(strings.TrimSuffix(token, "+"))
+		if err != nil {
+			return nil, err
+		}
+		return []constraint{{op: ">=", version: v}}, nil
+	}
+
+	// ~17.0.3 表示>=17.0.3 <17.1，~17 表示>=17 <18
+	if strings.HasPrefix(token, "~") {
+		v, err := Parse(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		idx := 1
+		if len(v.Components) < 2 {
+			idx = 0
+		}
+		return rangeConstraints(v, idx), nil
+	}
+
+	// ^17.0.1 表示>=17.0.1 <18
+	if strings.HasPrefix(token, "^") {
+		v, err := Parse(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		return rangeConstraints(v, 0), nil
+	}
+
+	v, err := Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	// 带预发布标识或构建号时要求完全相等
+	if v.Pre != "" || v.Build > 0 {
+		return []constraint{{op: "=", version: v}}, nil
+	}
+	return []constraint{{op: "prefix", version: v}}, nil
+}
+
+// rangeConstraints 生成从v到第idx部分加一之前的区间
+func rangeConstraints(v Version, idx int) []constraint {
+	upper := Version{Components: append([]int(nil), v.Components[:idx+1]...)}
+	upper.Components[idx]++
+	// 上限不包含预发布版本，例如<18不应包含18-ea
+	upper.Pre = "0"
+	return []constraint{
+		{op: ">=", version: v},
+		{op: "<", version: upper},
+	}
+}
+
+// matches 检查版本是否满足约束
+func (c constraint) matches(v Version) bool {
+	if c.op == "prefix" {
+		for i, n := range c.version.Components {
+			if component(v.Components, i) != n {
+				return false
+			}
+		}
+		return true
+	}
+
+	// 约束没有构建号时忽略版本的构建号，例如=17.0.9匹配17.0.9+9
+	if c.version.Build == 0 {
+		v.Build = 0
+	}
+	cmp := Compare(v, c.version)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case ">=":
+		return cmp >= 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case "<":
+		return cmp < 0
+	}
+	return false
+}
+
+func isOperator(token string) bool {
+	switch token {
+	case ">=", "<=", "==", ">", "<", "=":
+		return true
+	}
+	return false
+}
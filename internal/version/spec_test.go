@@ -0,0 +1,64 @@
+package version
+
+import "testing"
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		matches []string
+		rejects []string
+	}{
+		{"17", []string{"17", "17.0.9", "17.0.9+9"}, []string{"11.0.2", "18", "1.8.0_392"}},
+		{"17.0", []string{"17.0.9", "17"}, []string{"17.1.0", "18"}},
+		{"8", []string{"1.8.0_392", "8.0.392"}, []string{"11"}},
+		{"17+", []string{"17", "21.0.1", "22-ea"}, []string{"11.0.2", "17-ea"}},
+		{">=11 <21", []string{"11", "17.0.9", "20.0.2"}, []string{"8", "21", "21-ea"}},
+		{">= 11, < 21", []string{"11", "17"}, []string{"21"}},
+		{"~17.0", []string{"17.0.1", "17.0.9+9"}, []string{"17.1", "18", "16.0.2"}},
+		{"~17", []string{"17.0.9", "17.2"}, []string{"18", "18-ea"}},
+		{"^17", []string{"17", "17.0.9", "17.4.1"}, []string{"18", "16", "18-ea"}},
+		{"^17.0.5", []string{"17.0.5", "17.0.9"}, []string{"17.0.4", "18"}},
+		{"=17.0.9", []string{"17.0.9", "17.0.9+9"}, []string{"17.0.10", "17.0.9-ea"}},
+		{"17.0.9+9", []string{"17.0.9+9"}, []string{"17.0.9", "17.0.9+7"}},
+		{">=17.0.9+9", []string{"17.0.9+9", "17.0.10"}, []string{"17.0.9+8"}},
+		{"21-ea+3", []string{"21-ea+3"}, []string{"21", "21-ea+4"}},
+		{"*", []string{"8", "21-ea"}, nil},
+		{"latest", []string{"17"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSpec(%q) error: %v", tt.spec, err)
+			}
+			for _, v := range tt.matches {
+				if !spec.MatchesString(v) {
+					t.Errorf("%q should match %q", tt.spec, v)
+				}
+			}
+			for _, v := range tt.rejects {
+				if spec.MatchesString(v) {
+					t.Errorf("%q should not match %q", tt.spec, v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSpecInvalid(t *testing.T) {
+	for _, spec := range []string{">=", "~", "^x", "abc", ">=11 <x"} {
+		if _, err := ParseSpec(spec); err == nil {
+			t.Errorf("ParseSpec(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestMatchesStringUnparsable(t *testing.T) {
+	spec, err := ParseSpec("17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.MatchesString("unknown") {
+		t.Error("unparsable versions should not match")
+	}
+}
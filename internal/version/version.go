@@ -0,0 +1,184 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version 按JEP 322解析的Java版本号
+// 旧格式1.8.0_392会转换为8.0.392
+type Version struct {
+	Components []int  // 版本号各部分，例如17.0.9为[17 0 9]
+	Pre        string // 预发布标识，例如ea
+	Build      int    // 构建号，例如+9
+	Optional   string // 附加信息，例如LTS
+	Raw        string // 原始字符串
+}
+
+// Parse 解析Java版本号，支持1.8.0_392、17.0.9+9、21-ea+3、22.0.1.0.1等格式
+func Parse(s string) (Version, error) {
+	v := Version{Raw: s}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return v, fmt.Errorf("empty version")
+	}
+
+	// 拆分数字部分和后缀
+	end := 0
+	for end < len(s) && (isDigit(s[end]) || s[end] == '.') {
+		end++
+	}
+	numeric, rest := strings.Trim(s[:end], "."), s[end:]
+	if numeric == "" {
+		return v, fmt.Errorf("invalid version %q", s)
+	}
+	for _, part := range strings.Split(numeric, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		v.Components = append(v.Components, n)
+	}
+
+	// 旧格式：1.8.0_392-b08
+	legacy := len(v.Components) >= 2 && v.Components[0] == 1
+	if legacy {
+		v.Components = v.Components[1:]
+		if strings.HasPrefix(rest, "_") {
+			update, tail := leadingNumber(rest[1:])
+			if len(v.Components) < 2 {
+				v.Components = append(v.Components, 0)
+			}
+			v.Components = append(v.Components[:2], update)
+			rest = tail
+		}
+		if strings.HasPrefix(rest, "-b") {
+			if build, tail := leadingNumber(rest[2:]); tail != rest[2:] {
+				v.Build = build
+				rest = tail
+			}
+		}
+	}
+
+	// 预发布标识：-ea
+	if strings.HasPrefix(rest, "-") {
+		pre := rest[1:]
+		if idx := strings.IndexAny(pre, "+-"); idx != -1 {
+			pre, rest = pre[:idx], pre[idx:]
+		} else {
+			rest = ""
+		}
+		v.Pre = pre
+	}
+
+	// 构建号：+9
+	if strings.HasPrefix(rest, "+") {
+		build, tail := leadingNumber(rest[1:])
+		v.Build = build
+		rest = tail
+	}
+
+	v.Optional = strings.TrimLeft(rest, "-+_")
+	return v, nil
+}
+
+// Feature 返回特性版本号（主版本号）
+func (v Version) Feature() int {
+	if len(v.Components) == 0 {
+		return 0
+	}
+	return v.Components[0]
+}
+
+// String 返回规范化后的版本号
+func (v Version) String() string {
+	parts := make([]string, len(v.Components))
+	for i, n := range v.Components {
+		parts[i] = strconv.Itoa(n)
+	}
+	s := strings.Join(parts, ".")
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	if v.Build > 0 {
+		s += "+" + strconv.Itoa(v.Build)
+	}
+	return s
+}
+
+// Compare 按数值比较两个版本，返回-1、0或1
+// 末尾的0不影响比较，预发布版本低于正式版本
+func Compare(a, b Version) int {
+	if c := compareComponents(a.Components, b.Components); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.Pre == "" && b.Pre != "":
+		return 1
+	case a.Pre != "" && b.Pre == "":
+		return -1
+	case a.Pre != b.Pre:
+		return strings.Compare(a.Pre, b.Pre)
+	}
+
+	return compareInt(a.Build, b.Build)
+}
+
+// CompareStrings 比较两个版本字符串，无法解析的版本排在可解析版本之前
+func CompareStrings(a, b string) int {
+	va, errA := Parse(a)
+	vb, errB := Parse(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	return Compare(va, vb)
+}
+
+// compareComponents 逐个比较版本号各部分，缺少的部分视为0
+func compareComponents(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if c := compareInt(component(a, i), component(b, i)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// component 返回第i个部分，不存在时返回0
+func component(parts []int, i int) int {
+	if i < len(parts) {
+		return parts[i]
+	}
+	return 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// leadingNumber 读取字符串开头的数字，返回数字和剩余部分
+func leadingNumber(s string) (int, string) {
+	end := 0
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n, s[end:]
+}
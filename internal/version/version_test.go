@@ -0,0 +1,93 @@
+package version
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     []int
+		pre      string
+		build    int
+		optional string
+	}{
+		{"17", []int{17}, "", 0, ""},
+		{"17.0.9", []int{17, 0, 9}, "", 0, ""},
+		{"17.0.9+9", []int{17, 0, 9}, "", 9, ""},
+		{"21-ea+3", []int{21}, "ea", 3, ""},
+		{"21-ea", []int{21}, "ea", 0, ""},
+		{"1.8.0_392", []int{8, 0, 392}, "", 0, ""},
+		{"1.8.0_392-b08", []int{8, 0, 392}, "", 8, ""},
+		{"1.8", []int{8}, "", 0, ""},
+		{"22.0.1.0.1", []int{22, 0, 1, 0, 1}, "", 0, ""},
+		{"17.0.9+9-LTS", []int{17, 0, 9}, "", 9, "LTS"},
+		{" 11.0.2 ", []int{11, 0, 2}, "", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(v.Components, tt.want) {
+				t.Errorf("Components = %v, want %v", v.Components, tt.want)
+			}
+			if v.Pre != tt.pre {
+				t.Errorf("Pre = %q, want %q", v.Pre, tt.pre)
+			}
+			if v.Build != tt.build {
+				t.Errorf("Build = %d, want %d", v.Build, tt.build)
+			}
+			if v.Optional != tt.optional {
+				t.Errorf("Optional = %q, want %q", v.Optional, tt.optional)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{"", "   ", "abc", "ea", "-17"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"17", "17.0.0", 0},
+		{"17.0.9", "17.0.10", -1},
+		{"21", "17.0.9", 1},
+		{"1.8.0_392", "8.0.392", 0},
+		{"1.8.0_392", "11", -1},
+		{"21-ea+3", "21", -1},
+		{"21-ea+3", "21-ea+4", -1},
+		{"17.0.9+9", "17.0.9+7", 1},
+		{"17.0.9", "17.0.9+9", -1},
+		{"22.0.1.0.1", "22.0.1", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			if got := CompareStrings(tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareStrings(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := CompareStrings(tt.b, tt.a); got != -tt.want {
+				t.Errorf("CompareStrings(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareStringsUnparsable(t *testing.T) {
+	if got := CompareStrings("unknown", "8"); got != -1 {
+		t.Errorf("CompareStrings(unknown, 8) = %d, want -1", got)
+	}
+	if got := CompareStrings("b", "a"); got != 1 {
+		t.Errorf("CompareStrings(b, a) = %d, want 1", got)
+	}
+}